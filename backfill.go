@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hanjm/errors"
+	"github.com/hanjm/vlight/config"
+	"github.com/hanjm/vlight/store"
+)
+
+// backfillHTTPClient is this file's own client for eastmoney's lsjz
+// endpoint; it's a separate source from provider.Provider since backfill
+// needs history, not a current estimate.
+var backfillHTTPClient = &http.Client{Timeout: time.Minute}
+
+// lsjzResponse is the subset of eastmoney's public 历史净值 (lsjz)
+// endpoint this command needs.
+type lsjzResponse struct {
+	Data struct {
+		LSJZList []struct {
+			// FSRQ is the净值日期 (net-value date), e.g. "2024-01-02".
+			FSRQ string `json:"FSRQ"`
+			// DWJZ is 单位净值 (unit net value).
+			DWJZ string `json:"DWJZ"`
+			// JZZZL is 日增长率 (daily growth rate, %%), empty for the
+			// oldest row in the series.
+			JZZZL string `json:"JZZZL"`
+		} `json:"LSJZList"`
+	} `json:"Data"`
+}
+
+// FetchFundHistory fetches up to days of historical unit net values for
+// code from eastmoney's public lsjz endpoint, oldest first.
+func FetchFundHistory(ctx context.Context, code string, days int) ([]store.Snapshot, error) {
+	reqURL := fmt.Sprintf("http://api.fund.eastmoney.com/f10/lsjz?fundCode=%s&pageIndex=1&pageSize=%d", code, days)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, errors.Errorf(err, "new request, url:%s", reqURL)
+	}
+	resp, err := backfillHTTPClient.Do(req)
+	if err != nil {
+		return nil, errors.Errorf(err, "do request, url:%s", reqURL)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Errorf(err, "read body")
+	}
+	var parsed lsjzResponse
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return nil, errors.Errorf(err, "unmarshal, body:%s", body)
+	}
+	snapshots := make([]store.Snapshot, 0, len(parsed.Data.LSJZList))
+	// lsjz最新的在前,反转成从旧到新,与store.History的排序约定一致
+	for i := len(parsed.Data.LSJZList) - 1; i >= 0; i-- {
+		row := parsed.Data.LSJZList[i]
+		var dwjz, gszzl float64
+		if _, err := fmt.Sscanf(row.DWJZ, "%f", &dwjz); err != nil {
+			continue
+		}
+		fmt.Sscanf(row.JZZZL, "%f", &gszzl)
+		snapshots = append(snapshots, store.Snapshot{
+			FundCode: code,
+			Dwjz:     dwjz,
+			Gsz:      dwjz,
+			Gszzl:    gszzl,
+			Gztime:   row.FSRQ + " 15:00",
+		})
+	}
+	return snapshots, nil
+}
+
+// runBackfillCmd implements `vlight backfill --days=90`: it seeds the
+// history store from each watched fund's public net-value history so
+// strategy.DefaultRules has data to evaluate against on day one.
+func runBackfillCmd(args []string) {
+	fs := flag.NewFlagSet("backfill", flag.ExitOnError)
+	days := fs.Int("days", 90, "number of days of history to backfill")
+	configFlag := fs.String("config", "", "path to the YAML config file (defaults to VLIGHT_CONFIG)")
+	codesFlag := fs.String("codes", "", "comma separated fund codes (defaults to every code in -config's watch groups)")
+	storeFlag := fs.String("store", "", "sqlite store path (defaults to -config's store_path when -codes is not set, or vlight.db)")
+	if err := fs.Parse(args); err != nil {
+		log.Fatal(err)
+	}
+
+	var codes []string
+	storePath := *storeFlag
+	if *codesFlag != "" {
+		codes = strings.Split(*codesFlag, ",")
+	} else {
+		configPath := config.ResolvePath(*configFlag)
+		if configPath == "" {
+			log.Fatal("backfill requires -codes or -config (or VLIGHT_CONFIG)")
+		}
+		cfg, err := config.Load(configPath)
+		if err != nil {
+			log.Fatalf("failed to load config, path:%s, err:%s", configPath, err)
+		}
+		for _, group := range cfg.WatchGroups {
+			codes = append(codes, group.FundCodes...)
+		}
+		if storePath == "" {
+			storePath = cfg.StorePath
+		}
+	}
+	if storePath == "" {
+		storePath = "vlight.db"
+	}
+
+	st, err := store.NewSQLiteStore(storePath)
+	if err != nil {
+		log.Fatalf("failed to open store, path:%s, err:%s", storePath, err)
+	}
+	defer func() {
+		_ = st.Close()
+	}()
+
+	ctx := context.Background()
+	for _, code := range codes {
+		snapshots, err := FetchFundHistory(ctx, code, *days)
+		if err != nil {
+			log.Printf("[E]fetch history, code:%s, err:%s", code, err)
+			continue
+		}
+		for _, snapshot := range snapshots {
+			if err := st.Save(ctx, snapshot); err != nil {
+				log.Printf("[E]save snapshot, code:%s, gztime:%s, err:%s", code, snapshot.Gztime, err)
+			}
+		}
+		log.Printf("backfilled code:%s, days:%d, rows:%d", code, *days, len(snapshots))
+	}
+}
@@ -0,0 +1,119 @@
+// Package config loads vlight's YAML configuration: watch groups with
+// per-fund thresholds, notifier credentials, and per-fund overrides. It
+// replaces the flat FOUND_CODES/SMTP_*/SCKEY env vars main() used to
+// read directly.
+package config
+
+// Config is the root of the YAML config file.
+type Config struct {
+	// WatchGroups lists the groups of funds to poll, each with its own
+	// thresholds and notifier selection.
+	WatchGroups []WatchGroup `yaml:"watch_groups"`
+	// Notifiers holds the credentials for every notifier backend that
+	// may be referenced by name from a WatchGroup.
+	Notifiers NotifiersConfig `yaml:"notifiers"`
+	// FundOverrides maps fundcode to per-fund settings, e.g. hold cost
+	// so alerts can be based on P&L instead of daily %.
+	FundOverrides map[string]FundOverride `yaml:"fund_overrides"`
+	// StorePath is the SQLite file used to persist fund snapshots for
+	// strategy evaluation. Defaults to "vlight.db" if empty.
+	StorePath string `yaml:"store_path"`
+	// ListenAddr is where the daemon's httpserver mounts /metrics,
+	// /healthz, /readyz and /funds. Defaults to ":9100" if empty. One-shot
+	// -serve runs use the -listen flag instead.
+	ListenAddr string `yaml:"listen_addr"`
+	// Providers lists the fund data providers to try in order, e.g.
+	// ["eastmoney", "tencent", "sina"]. Defaults to that same order if
+	// empty; Validate rejects unknown names.
+	Providers []string `yaml:"providers"`
+
+	// path is the file this Config was loaded from, kept so Watch can
+	// re-read it on change without the caller repeating themselves.
+	path string
+}
+
+// WatchGroup is a named set of funds polled together against the same
+// thresholds and notified through the same notifiers.
+type WatchGroup struct {
+	// Name identifies the group in logs and daily/weekly summaries.
+	Name string `yaml:"name"`
+	// FundCodes are the fundgz.1234567.com.cn codes to poll.
+	FundCodes []string `yaml:"fund_codes"`
+	// MinRise is the daily Gszzl %% at or above which a fund is
+	// flagged 涨 (rise).
+	MinRise float64 `yaml:"min_rise"`
+	// MaxFall is the daily Gszzl %% at or below which a fund is
+	// flagged 跌 (fall).
+	MaxFall float64 `yaml:"max_fall"`
+	// QuietHours suppresses notifications while the current time
+	// falls in any of these windows.
+	QuietHours []QuietHour `yaml:"quiet_hours"`
+	// Notifiers lists the notifier names (keys under NotifiersConfig)
+	// to fire for this group. Empty means every enabled notifier.
+	Notifiers []string `yaml:"notifiers"`
+}
+
+// QuietHour is a daily time-of-day window, e.g. "22:00"-"07:00".
+type QuietHour struct {
+	Start string `yaml:"start"`
+	End   string `yaml:"end"`
+}
+
+// FundOverride customizes alerting for a single fund.
+type FundOverride struct {
+	// HoldCost is the user's cost-basis unit net value. When set, the
+	// fund's 涨/跌 status in the report is classified by P&L vs cost
+	// rather than just the daily %%.
+	HoldCost float64 `yaml:"hold_cost"`
+}
+
+// NotifiersConfig holds the credentials for every notifier backend.
+// Each field is a pointer so its presence indicates the notifier is
+// configured; WatchGroup.Notifiers selects which ones fire per group.
+type NotifiersConfig struct {
+	Email           *EmailConfig      `yaml:"email"`
+	ServerChan      *ServerChanConfig `yaml:"serverchan"`
+	ServerChanTurbo *ServerChanConfig `yaml:"serverchan_turbo"`
+	WeCom           *WeComConfig      `yaml:"wecom"`
+	DingTalk        *DingTalkConfig   `yaml:"dingtalk"`
+	Telegram        *TelegramConfig   `yaml:"telegram"`
+	Bark            *BarkConfig       `yaml:"bark"`
+}
+
+// EmailConfig holds SMTP credentials for the email notifier.
+type EmailConfig struct {
+	SMTPHost string `yaml:"smtp_host"`
+	SMTPPort int    `yaml:"smtp_port"`
+	From     string `yaml:"from"`
+	Password string `yaml:"password"`
+	To       string `yaml:"to"`
+}
+
+// ServerChanConfig holds the Server酱 (legacy or Turbo) push key.
+type ServerChanConfig struct {
+	Key string `yaml:"key"`
+}
+
+// WeComConfig holds a WeChat Work group bot webhook key.
+type WeComConfig struct {
+	Key string `yaml:"key"`
+}
+
+// DingTalkConfig holds a DingTalk custom robot's access token and, for
+// signed robots, its secret.
+type DingTalkConfig struct {
+	AccessToken string `yaml:"access_token"`
+	Secret      string `yaml:"secret"`
+}
+
+// TelegramConfig holds a Telegram bot token and destination chat id.
+type TelegramConfig struct {
+	BotToken string `yaml:"bot_token"`
+	ChatID   string `yaml:"chat_id"`
+}
+
+// BarkConfig holds a Bark device key and optional self-hosted server.
+type BarkConfig struct {
+	DeviceKey string `yaml:"device_key"`
+	Server    string `yaml:"server"`
+}
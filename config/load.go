@@ -0,0 +1,39 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+
+	"github.com/hanjm/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// EnvConfigPath is the env var fallback for -config when the flag isn't
+// set.
+const EnvConfigPath = "VLIGHT_CONFIG"
+
+// Load reads and parses the YAML config file at path.
+func Load(path string) (*Config, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Errorf(err, "read config, path:%s", path)
+	}
+	cfg := &Config{}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, errors.Errorf(err, "unmarshal config, path:%s", path)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Errorf(err, "validate config, path:%s", path)
+	}
+	cfg.path = path
+	return cfg, nil
+}
+
+// ResolvePath returns the configured path, preferring the -config flag
+// value and falling back to VLIGHT_CONFIG.
+func ResolvePath(flagValue string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	return os.Getenv(EnvConfigPath)
+}
@@ -0,0 +1,91 @@
+package config
+
+import (
+	"github.com/hanjm/errors"
+)
+
+// Validate checks that every notifier name referenced by a WatchGroup
+// has matching credentials configured under Notifiers, and that every
+// configured notifier's required fields are non-empty. It is meant to
+// be called at startup so a typo'd notifier name or missing credential
+// fails fast instead of silently dropping alerts.
+func (c *Config) Validate() error {
+	for _, group := range c.WatchGroups {
+		if len(group.FundCodes) == 0 {
+			return errors.Errorf(nil, "watch group %q has no fund_codes", group.Name)
+		}
+		for _, name := range group.Notifiers {
+			if !c.Notifiers.has(name) {
+				return errors.Errorf(nil, "watch group %q references unconfigured notifier %q", group.Name, name)
+			}
+		}
+	}
+	for _, name := range c.Providers {
+		if !validProviderNames[name] {
+			return errors.Errorf(nil, "providers references unknown provider %q", name)
+		}
+	}
+	return c.Notifiers.validate()
+}
+
+// validProviderNames enumerates the fund data providers main() knows how
+// to build; kept in config so Validate can fail fast on a typo.
+var validProviderNames = map[string]bool{
+	"eastmoney": true,
+	"tencent":   true,
+	"sina":      true,
+}
+
+// has reports whether the notifier name has a section configured under
+// Notifiers; validate checks that section's required fields.
+func (n NotifiersConfig) has(name string) bool {
+	switch name {
+	case "email":
+		return n.Email != nil
+	case "serverchan":
+		return n.ServerChan != nil
+	case "serverchan_turbo":
+		return n.ServerChanTurbo != nil
+	case "wecom":
+		return n.WeCom != nil
+	case "dingtalk":
+		return n.DingTalk != nil
+	case "telegram":
+		return n.Telegram != nil
+	case "bark":
+		return n.Bark != nil
+	default:
+		return false
+	}
+}
+
+// validate checks each configured notifier's required credential
+// fields are non-empty.
+func (n NotifiersConfig) validate() error {
+	if e := n.Email; e != nil {
+		if e.SMTPHost == "" || e.From == "" || e.Password == "" || e.To == "" {
+			return errors.Errorf(nil, "notifiers.email requires smtp_host, from, password and to")
+		}
+	}
+	if s := n.ServerChan; s != nil && s.Key == "" {
+		return errors.Errorf(nil, "notifiers.serverchan requires key")
+	}
+	if s := n.ServerChanTurbo; s != nil && s.Key == "" {
+		return errors.Errorf(nil, "notifiers.serverchan_turbo requires key")
+	}
+	if w := n.WeCom; w != nil && w.Key == "" {
+		return errors.Errorf(nil, "notifiers.wecom requires key")
+	}
+	if d := n.DingTalk; d != nil && d.AccessToken == "" {
+		return errors.Errorf(nil, "notifiers.dingtalk requires access_token")
+	}
+	if t := n.Telegram; t != nil {
+		if t.BotToken == "" || t.ChatID == "" {
+			return errors.Errorf(nil, "notifiers.telegram requires bot_token and chat_id")
+		}
+	}
+	if b := n.Bark; b != nil && b.DeviceKey == "" {
+		return errors.Errorf(nil, "notifiers.bark requires device_key")
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package config
+
+import "testing"
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{
+			name: "正常配置",
+			cfg: Config{
+				WatchGroups: []WatchGroup{{Name: "g1", FundCodes: []string{"000001"}, Notifiers: []string{"email"}}},
+				Notifiers:   NotifiersConfig{Email: &EmailConfig{SMTPHost: "smtp.example.com", From: "a@example.com", Password: "x", To: "a@example.com"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "group缺少fund_codes",
+			cfg: Config{
+				WatchGroups: []WatchGroup{{Name: "g1"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "group引用未配置的notifier",
+			cfg: Config{
+				WatchGroups: []WatchGroup{{Name: "g1", FundCodes: []string{"000001"}, Notifiers: []string{"wecom"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "providers引用未知provider",
+			cfg: Config{
+				WatchGroups: []WatchGroup{{Name: "g1", FundCodes: []string{"000001"}}},
+				Providers:   []string{"yahoo"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "email配置缺少必填字段",
+			cfg: Config{
+				WatchGroups: []WatchGroup{{Name: "g1", FundCodes: []string{"000001"}}},
+				Notifiers:   NotifiersConfig{Email: &EmailConfig{SMTPHost: "smtp.example.com"}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := c.cfg.Validate()
+			if (err != nil) != c.wantErr {
+				t.Fatalf("Validate() err = %v, wantErr %v", err, c.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package config
+
+import (
+	"context"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/hanjm/errors"
+)
+
+// reloadRetryInterval/reloadRetries bound how long Watch retries loading
+// the config after a Remove/Rename event, since the common atomic-save
+// pattern (write a temp file, then rename it over the target) fires
+// Remove on the watched path an instant before the replacement file
+// lands.
+const (
+	reloadRetryInterval = 100 * time.Millisecond
+	reloadRetries       = 20
+)
+
+// Watch watches the directory containing the file c was loaded from and
+// invokes onReload with the newly parsed Config whenever that file
+// changes. It blocks until ctx is cancelled or the watcher fails, so
+// callers should run it in its own goroutine. A config that fails to
+// parse or validate after an edit is logged and skipped rather than
+// propagated, so a bad save doesn't crash a running daemon.
+//
+// The directory, not the file itself, is watched because the common
+// atomic-save pattern (vim, most editors, and k8s ConfigMap symlink
+// swaps: write a temp file, then rename/remove it over the target)
+// replaces the watched inode outright. Watching the file directly means
+// that Remove is never followed by another event on it, silently
+// killing hot-reload.
+func (c *Config) Watch(ctx context.Context, onReload func(*Config)) error {
+	path := c.path
+	dir := filepath.Dir(path)
+	name := filepath.Base(path)
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return errors.Errorf(err, "new watcher")
+	}
+	defer func() {
+		_ = watcher.Close()
+	}()
+	if err := watcher.Add(dir); err != nil {
+		return errors.Errorf(err, "watch dir:%s", dir)
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if filepath.Base(event.Name) != name {
+				continue
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename|fsnotify.Remove) == 0 {
+				continue
+			}
+			// rename/remove后重建文件:新文件此刻可能还不存在,短暂重试
+			retry := event.Op&(fsnotify.Remove|fsnotify.Rename) != 0
+			cfg, err := loadWithRetry(path, retry)
+			if err != nil {
+				log.Printf("[E]failed to reload config, path:%s, err:%s", path, err)
+				continue
+			}
+			log.Printf("reloaded config, path:%s", path)
+			onReload(cfg)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			log.Printf("[E]config watcher error, err:%s", err)
+		}
+	}
+}
+
+// loadWithRetry loads path, retrying while the file is momentarily
+// missing (os.IsNotExist) when retry is set. It gives up immediately on
+// any other error, including a malformed config.
+func loadWithRetry(path string, retry bool) (*Config, error) {
+	cfg, err := Load(path)
+	if !retry || err == nil {
+		return cfg, err
+	}
+	for i := 0; i < reloadRetries && errors.Is(err, os.ErrNotExist); i++ {
+		time.Sleep(reloadRetryInterval)
+		cfg, err = Load(path)
+		if err == nil {
+			return cfg, nil
+		}
+	}
+	return cfg, err
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/hanjm/errors"
+	"github.com/hanjm/vlight/config"
+	"github.com/hanjm/vlight/httpserver"
+	"github.com/hanjm/vlight/notifier"
+	"github.com/hanjm/vlight/scheduler"
+	"github.com/hanjm/vlight/store"
+	"github.com/hanjm/vlight/strategy"
+)
+
+// historyWindowDays is the lookback in calendar days. It covers the
+// longest lookback any DefaultRules rule needs (20 trading days for the
+// moving average / new low) even when the window spans a long holiday
+// closure.
+const historyWindowDays = 45
+
+// daemonState holds the config reloaded by cfg.Watch behind a mutex so
+// scheduled jobs always see the latest watch groups and credentials.
+type daemonState struct {
+	mu        sync.RWMutex
+	cfg       *config.Config
+	notifiers map[string]notifier.Notifier
+}
+
+func newDaemonState(cfg *config.Config) *daemonState {
+	setActiveProvider(cfg.Providers)
+	return &daemonState{cfg: cfg, notifiers: notifiersFromConfig(cfg.Notifiers)}
+}
+
+func (d *daemonState) set(cfg *config.Config) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.cfg = cfg
+	d.notifiers = notifiersFromConfig(cfg.Notifiers)
+	setActiveProvider(cfg.Providers)
+}
+
+func (d *daemonState) get() (*config.Config, map[string]notifier.Notifier) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.cfg, d.notifiers
+}
+
+// runDaemon runs vlight as a long-lived process: a cron scheduler polls
+// every watch group during market hours, sends a daily summary after
+// close, and a weekly digest on Fridays. It blocks until ctx is
+// cancelled.
+func runDaemon(ctx context.Context, configPath string, holidaysPath string) error {
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return errors.Errorf(err, "load config, path:%s", configPath)
+	}
+	holidays, err := scheduler.LoadHolidays(holidaysPath)
+	if err != nil {
+		return errors.Errorf(err, "load holidays, path:%s", holidaysPath)
+	}
+	sched, err := scheduler.New(holidays)
+	if err != nil {
+		return errors.Errorf(err, "new scheduler")
+	}
+	storePath := cfg.StorePath
+	if storePath == "" {
+		storePath = "vlight.db"
+	}
+	st, err := store.NewSQLiteStore(storePath)
+	if err != nil {
+		return errors.Errorf(err, "open store, path:%s", storePath)
+	}
+	defer func() {
+		_ = st.Close()
+	}()
+	state := newDaemonState(cfg)
+	aggregator := scheduler.NewWeeklyAggregator()
+
+	listenAddr := cfg.ListenAddr
+	if listenAddr == "" {
+		listenAddr = ":9100"
+	}
+	srv := httpserver.New(listenAddr)
+	go func() {
+		if err := srv.Start(); err != nil {
+			log.Printf("[E]httpserver stopped, err:%s", err)
+		}
+	}()
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	go func() {
+		if err := cfg.Watch(ctx, state.set); err != nil {
+			log.Printf("[E]config watch stopped, err:%s", err)
+		}
+	}()
+
+	if err := sched.AddPollJob(scheduler.DefaultPollSpec, func(jobCtx context.Context) {
+		pollAllGroups(jobCtx, state, st, aggregator, srv, false)
+	}); err != nil {
+		return err
+	}
+	if err := sched.AddDailyJob(scheduler.DefaultDailySummarySpec, func(jobCtx context.Context) {
+		pollAllGroups(jobCtx, state, st, aggregator, srv, true)
+	}); err != nil {
+		return err
+	}
+	if err := sched.AddDailyJob(scheduler.DefaultWeeklyDigestSpec, func(jobCtx context.Context) {
+		sendWeeklyDigest(jobCtx, state, aggregator)
+		aggregator.Reset()
+	}); err != nil {
+		return err
+	}
+
+	sched.Start()
+	log.Printf("daemon started, config:%s, listen:%s", configPath, listenAddr)
+	<-ctx.Done()
+	sched.Stop()
+	return nil
+}
+
+// pollAllGroups fetches and notifies every watch group, persisting each
+// fund's snapshot and evaluating strategy.DefaultRules against its
+// history before sending. recordDaily is true only for the once-daily
+// summary job, so the weekly aggregator accumulates one Gszzl sample per
+// fund per trading day instead of one per 5-minute poll. srv.SetFunds is
+// updated with every fund seen this round so /funds always reflects the
+// latest poll.
+func pollAllGroups(ctx context.Context, state *daemonState, st store.Store, aggregator *scheduler.WeeklyAggregator, srv *httpserver.Server, recordDaily bool) {
+	cfg, allNotifiers := state.get()
+	var allFunds []Fund
+	for _, group := range cfg.WatchGroups {
+		registry := registryForGroup(allNotifiers, group)
+		funds, err := pollGroup(ctx, group, registry, st, aggregator, recordDaily, cfg.FundOverrides)
+		if err != nil {
+			log.Printf("[E]%s", err)
+			continue
+		}
+		allFunds = append(allFunds, funds...)
+	}
+	srv.SetFunds(allFunds)
+	srv.SetReady(true)
+}
+
+// pollGroup fetches a single watch group, persists and evaluates each
+// fund against strategy.DefaultRules, then notifies with the daily
+// threshold table annotated with any alerts that fired. recordDaily
+// gates whether this round's Gszzl is fed into the weekly aggregator
+// (see pollAllGroups). It returns the fetched funds so the caller can
+// publish them at /funds.
+func pollGroup(ctx context.Context, group config.WatchGroup, registry *notifier.Registry, st store.Store, aggregator *scheduler.WeeklyAggregator, recordDaily bool, overrides map[string]config.FundOverride) ([]Fund, error) {
+	log.Printf("watch group:%s fundCodes:%v", group.Name, group.FundCodes)
+	funds, err := FetchFunds(ctx, group.FundCodes)
+	if err != nil {
+		return nil, errors.Errorf(err, "group:%s", group.Name)
+	}
+	since := time.Now().AddDate(0, 0, -historyWindowDays).Format("2006-01-02")
+	var notes []notifier.Note
+	for _, fund := range funds {
+		if recordDaily {
+			aggregator.Record(fund.Name, fund.Gszzl)
+		}
+		current := store.Snapshot{FundCode: fund.FundCode, Name: fund.Name, Dwjz: fund.Dwjz, Gsz: fund.Gsz, Gszzl: fund.Gszzl, Gztime: fund.Gztime}
+		history, err := st.History(ctx, fund.FundCode, since)
+		if err != nil {
+			log.Printf("[E]load history, fundcode:%s, err:%s", fund.FundCode, err)
+		} else {
+			// 规则按交易日计算:每个交易日只保留最后一次快照,current当天更早的轮询不计入
+			var daily []store.Snapshot
+			for _, s := range history {
+				if len(s.Gztime) < 10 || len(current.Gztime) < 10 || s.Gztime[:10] == current.Gztime[:10] {
+					continue
+				}
+				if n := len(daily); n > 0 && daily[n-1].Gztime[:10] == s.Gztime[:10] {
+					daily[n-1] = s
+				} else {
+					daily = append(daily, s)
+				}
+			}
+			for _, alert := range strategy.Evaluate(daily, current, strategy.DefaultRules()) {
+				notes = append(notes, notifier.Note{Title: alert.Rule, Message: alert.Message})
+			}
+		}
+		if err := st.Save(ctx, current); err != nil {
+			log.Printf("[E]save snapshot, fundcode:%s, err:%s", fund.FundCode, err)
+		}
+	}
+	if inQuietHours(time.Now(), group.QuietHours) {
+		log.Printf("watch group:%s is in quiet hours, skip", group.Name)
+		return funds, nil
+	}
+	report := buildReport(funds, group.MinRise, group.MaxFall, overrides)
+	report.Notes = notes
+	if err := registry.Send(ctx, report); err != nil {
+		return funds, errors.Errorf(err, "group:%s", group.Name)
+	}
+	return funds, nil
+}
+
+// sendWeeklyDigest renders the week's cumulative Gszzl per fund and
+// sends it through every watch group's notifiers.
+func sendWeeklyDigest(ctx context.Context, state *daemonState, aggregator *scheduler.WeeklyAggregator) {
+	cfg, allNotifiers := state.get()
+	sums := aggregator.Digest()
+	if len(sums) == 0 {
+		return
+	}
+	statuses := make([]notifier.FundStatus, 0, len(sums))
+	for name, sum := range sums {
+		statuses = append(statuses, notifier.NewFundStatus(name, sum, 0, 0, "本周累计", 0, 0, 0))
+	}
+	report := notifier.Report{Funds: statuses, GeneratedAt: time.Now()}
+	for _, group := range cfg.WatchGroups {
+		registry := registryForGroup(allNotifiers, group)
+		if err := registry.Send(ctx, report); err != nil {
+			log.Printf("[E]group:%s weekly digest, err:%s", group.Name, err)
+		}
+	}
+}
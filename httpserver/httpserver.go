@@ -0,0 +1,101 @@
+// Package httpserver exposes vlight's Prometheus metrics and a small
+// health/readiness/latest-snapshot HTTP API so the tool is observable
+// and composable with existing monitoring stacks instead of being a
+// black box that only sends alerts.
+package httpserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+
+	"github.com/hanjm/errors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Server mounts /metrics, /healthz, /readyz and /funds on a single
+// listen address.
+type Server struct {
+	httpServer *http.Server
+	ready      int32
+
+	mu    sync.RWMutex
+	funds interface{}
+}
+
+// New builds a Server listening on addr. It does not start listening
+// until Start is called.
+func New(addr string) *Server {
+	s := &Server{}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.HandleFunc("/healthz", s.handleHealthz)
+	mux.HandleFunc("/readyz", s.handleReadyz)
+	mux.HandleFunc("/funds", s.handleFunds)
+	s.httpServer = &http.Server{Addr: addr, Handler: mux}
+	return s
+}
+
+// SetFunds replaces the JSON payload served at /funds with the latest
+// poll's result.
+func (s *Server) SetFunds(funds interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.funds = funds
+}
+
+// SetReady marks the server ready (or not) for /readyz, e.g. toggled
+// once the first successful poll completes.
+func (s *Server) SetReady(ready bool) {
+	var v int32
+	if ready {
+		v = 1
+	}
+	atomic.StoreInt32(&s.ready, v)
+}
+
+func (s *Server) handleHealthz(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok"))
+}
+
+func (s *Server) handleReadyz(w http.ResponseWriter, _ *http.Request) {
+	if atomic.LoadInt32(&s.ready) == 0 {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte("not ready"))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok"))
+}
+
+func (s *Server) handleFunds(w http.ResponseWriter, _ *http.Request) {
+	s.mu.RLock()
+	funds := s.funds
+	s.mu.RUnlock()
+	w.Header().Set("Content-Type", "application/json")
+	if funds == nil {
+		_, _ = w.Write([]byte("[]"))
+		return
+	}
+	_ = json.NewEncoder(w).Encode(funds)
+}
+
+// Start runs the HTTP server in the foreground; callers typically run
+// it in its own goroutine. It returns nil on a clean Shutdown.
+func (s *Server) Start() error {
+	if err := s.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return errors.Errorf(err, "listen and serve, addr:%s", s.httpServer.Addr)
+	}
+	return nil
+}
+
+// Shutdown gracefully stops the HTTP server.
+func (s *Server) Shutdown(ctx context.Context) error {
+	if err := s.httpServer.Shutdown(ctx); err != nil {
+		return errors.Errorf(err, "shutdown")
+	}
+	return nil
+}
@@ -1,90 +1,95 @@
 package main
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"github.com/hanjm/errors"
-	"golang.org/x/sync/errgroup"
-	"gopkg.in/gomail.v2"
-	"io/ioutil"
+	"flag"
 	"log"
-	"net/http"
-	"net/url"
 	"os"
 	"sort"
-	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
+
+	"github.com/hanjm/errors"
+	"github.com/hanjm/vlight/config"
+	"github.com/hanjm/vlight/httpserver"
+	"github.com/hanjm/vlight/metrics"
+	"github.com/hanjm/vlight/notifier"
+	"github.com/hanjm/vlight/provider"
 )
 
-// Fund
-// data example: jsonpgz({"fundcode":"180012","name":"閾跺崕瀵岃涓婚娣峰悎","jzrq":"2019-10-31","dwjz":"3.6009",
-// "gsz":"3.6490","gszzl":"1.34","gztime":"2019-11-01 15:00"});
-type Fund struct {
-	// 基金代码
-	FundCode string `json:"fundcode"`
-	// 基金名称
-	Name string `json:"name"`
-	// 截止日期
-	JzRq string `json:"jzrq"`
-	// (昨日)单位净值
-	Dwjz float64 `json:"dwjz,string"`
-	// (当前)估算净值
-	Gsz float64 `json:"gsz,string"`
-	// 估算增长率
-	Gszzl float64 `json:"gszzl,string"`
-	// 估值时间
-	Gztime string `json:"gztime"`
+// Fund is vlight's view of a fund's valuation; it's an alias of
+// provider.Fund so store, notifier and httpserver don't need to know
+// which upstream a fund came from.
+type Fund = provider.Fund
+
+var timeLocationCST = time.FixedZone("CST", 28800)
+
+// activeProvider holds the provider.Provider FetchFund delegates to. It's
+// an atomic.Value rather than a bare var because daemonState.set
+// reassigns it from the cfg.Watch goroutine on every config hot-reload
+// while FetchFunds reads it from up to 3 concurrent fetch goroutines.
+var activeProvider atomic.Value
+
+func init() {
+	activeProvider.Store(buildProviderChain(nil))
 }
 
-func (f Fund) String() string {
-	return fmt.Sprintf("%s-单位净值:%v-估算净值:%v-估算增长率:%v-估值时间:%s-截止日期:%s", f.Name, f.Dwjz, f.Gsz, f.Gszzl, f.Gztime, f.JzRq)
+// setActiveProvider rebuilds and installs the provider chain for the
+// given config.Providers selection.
+func setActiveProvider(names []string) {
+	activeProvider.Store(buildProviderChain(names))
 }
 
-var (
-	httpClient = &http.Client{
-		Timeout: time.Minute,
-	}
-	bodyPrefix = []byte("jsonpgz(")
-	bodySuffix = []byte(");")
-)
+// getActiveProvider returns the currently installed provider chain.
+func getActiveProvider() provider.Provider {
+	return activeProvider.Load().(provider.Provider)
+}
 
-// FetchFund
-func FetchFund(ctx context.Context, code string) (fund Fund, err error) {
-	reqURL := "http://fundgz.1234567.com.cn/js/" + code + ".js"
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
-	if err != nil {
-		err = errors.Errorf(err, "new request, url:%s", reqURL)
-		return
+// buildProviderChain resolves a config.Providers list into a
+// provider.MultiProvider that tries each named provider in order,
+// falling back to the eastmoney->tencent->sina order when names is
+// empty. Unknown names are logged and skipped.
+func buildProviderChain(names []string) provider.Provider {
+	available := map[string]provider.Provider{
+		"eastmoney": provider.NewEastMoneyProvider(),
+		"tencent":   provider.NewTencentProvider(),
+		"sina":      provider.NewSinaProvider(),
 	}
-	// 设置一个正常浏览器的ua
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_14_6) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/78.0.3904.70 Safari/537.36")
-	log.Printf("request url:%s", reqURL)
-	resp, err := httpClient.Do(req)
-	if err != nil {
-		err = errors.Errorf(err, "do request, url:%s", reqURL)
-		return
+	if len(names) == 0 {
+		names = []string{"eastmoney", "tencent", "sina"}
 	}
+	chain := make([]provider.Provider, 0, len(names))
+	for _, name := range names {
+		p, ok := available[name]
+		if !ok {
+			log.Printf("[W]unknown provider:%s, ignored", name)
+			continue
+		}
+		chain = append(chain, p)
+	}
+	return provider.NewMultiProvider(chain, provider.DefaultProviderTimeout)
+}
+
+// FetchFund fetches a single fund's valuation through activeProvider,
+// recording Prometheus metrics around the call.
+func FetchFund(ctx context.Context, code string) (fund Fund, err error) {
+	start := time.Now()
 	defer func() {
-		_ = resp.Body.Close()
+		metrics.FetchDuration.WithLabelValues(code).Observe(time.Since(start).Seconds())
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+		metrics.FetchTotal.WithLabelValues(code, result).Inc()
 	}()
-	body, err := ioutil.ReadAll(resp.Body)
+	fund, err = getActiveProvider().Fetch(ctx, code)
 	if err != nil {
-		err = errors.Errorf(err, "read body")
+		err = errors.Errorf(err, "code:%s", code)
 		return
 	}
-	// 处理body
-	body = bytes.TrimPrefix(body, bodyPrefix)
-	body = bytes.TrimSuffix(body, bodySuffix)
-	err = json.Unmarshal(body, &fund)
-	if err != nil {
-		err = errors.Errorf(err, "unmarshal, body:%s", body)
-		return
-	}
-	log.Printf("funds:%+v", fund)
+	metrics.FundGszzl.WithLabelValues(fund.FundCode, fund.Name).Set(fund.Gszzl)
 	return fund, nil
 }
 
@@ -136,160 +141,132 @@ func FetchFunds(ctx context.Context, codes []string) (funds []Fund, err error) {
 	return funds, <-errCh
 }
 
-// GenerateEmailHTML
-func GenerateEmailHTML(ctx context.Context, funds []Fund, minRiseNum float64, maxFallNum float64) (emailHtml string, shouldSend bool) {
-	var elements []string
-	var content string
+// buildReport classifies each fund against the configured thresholds and
+// wraps the result into a notifier.Report every backend can render. A
+// fund with a configured FundOverride.HoldCost is classified by its
+// unrealized P&L % against that cost instead of the daily Gszzl.
+func buildReport(funds []Fund, minRiseNum, maxFallNum float64, overrides map[string]config.FundOverride) notifier.Report {
+	statuses := make([]notifier.FundStatus, 0, len(funds))
 	for _, fund := range funds {
-		var status string
-		// 涨跌幅度超出设定值
-		if fund.Gszzl > 0 && fund.Gszzl >= minRiseNum {
-			status = "涨"
-		} else if fund.Gszzl < 0 && fund.Gszzl <= maxFallNum {
-			status = "跌"
-		} else {
-			status = "-"
-		}
-		element := `
-            <tr>
-              <td width="50" align="center">` + status + `</td>
-              <td width="50" align="center">` + fund.Name + `</td>
-              <td width="50" align="center">` + strconv.FormatFloat(fund.Gszzl, 'f', -1, 64) + `%</td>
-              <td width="50" align="center">` + strconv.FormatFloat(fund.Gsz, 'f', -1, 64) + `</td>
-              <td width="50" align="center">` + strconv.FormatFloat(fund.Dwjz, 'f', -1, 64) + `</td>
-              <td width="50" align="center">` + fund.Gztime + `</td>
-            </tr>
-			`
-		elements = append(elements, element)
+		holdCost := overrides[fund.FundCode].HoldCost
+		statuses = append(statuses, notifier.NewFundStatus(fund.Name, fund.Gszzl, fund.Gsz, fund.Dwjz, fund.Gztime, minRiseNum, maxFallNum, holdCost))
 	}
-	if len(elements) > 0 {
-		content = strings.Join(elements, "\n")
-		html := `
-			</html>
-				<head>
-					<meta http-equiv="Content-Type" content="text/html; charset=utf-8" />
-				</head>
-            <body>
-				<div id="container">
-					<p>基金涨跌监控:</p>
-					<div id="content">
-						<table width="30%" border="1" cellspacing="0" cellpadding="0">
-							<tr>
-							  <td width="50" align="center">状态</td>
-							  <td width="100" align="center">基金名称</td>
-							  <td width="50" align="center">估算涨幅</td>
-							  <td width="50" align="center">当前估算净值</td>
-							  <td width="50" align="center">昨日单位净值</td>
-							  <td width="50" align="center">估算时间</td>
-							</tr>` + content + `
-						</table>
-					</div>
-            	</div>
-            </div>
-            </body>
-        </html>`
-
-		return html, true
+	return notifier.Report{
+		Funds:       statuses,
+		MinRiseNum:  minRiseNum,
+		MaxFallNum:  maxFallNum,
+		GeneratedAt: time.Now(),
 	}
-
-	return "", false
 }
 
-var (
-	timeLocationCST = time.FixedZone("CST", 28800)
-)
-
-func SendEmail(ctx context.Context, content string, smtpHost string, emailName string, emailPassword string, emailTo string) (err error) {
-	if content == "" {
-		return
+// legacyRegistry wires up the notifier backends enabled via the legacy
+// SMTP_*/SCKEY env vars. This is a compatibility shim for users who
+// haven't migrated to the YAML config yet.
+func legacyRegistry() *notifier.Registry {
+	var notifiers []notifier.Notifier
+	smtpHost := os.Getenv("SMTP_HOST")
+	emailName := os.Getenv("EMAIL_NAME")
+	emailPassword := os.Getenv("EMAIL_PASSWORD")
+	emailTo := os.Getenv("EMAIL_TO")
+	if emailTo == "" {
+		emailTo = emailName
 	}
-	m := gomail.NewMessage()
-	m.SetHeader("From", emailName)
-	m.SetHeader("To", emailTo)
-	m.SetHeader("Subject", fmt.Sprintf("基金涨跌监控-%s", time.Now().In(timeLocationCST).Format(time.RFC3339)))
-	m.SetBody("text/html", content)
-	d := gomail.NewDialer(smtpHost, 587, emailName, emailPassword)
-	if err := d.DialAndSend(m); err != nil {
-		err = errors.Errorf(err, "content:%s", content)
-		return err
+	if smtpHost != "" && emailName != "" && emailPassword != "" && emailTo != "" {
+		notifiers = append(notifiers, notifier.NewEmailNotifier(smtpHost, 0, emailName, emailPassword, emailTo))
 	}
-	return nil
+	if serverChanKey := os.Getenv("SCKEY"); serverChanKey != "" {
+		notifiers = append(notifiers, notifier.NewServerChanNotifier(serverChanKey))
+	}
+	return notifier.NewRegistry(notifiers...)
 }
 
-// GenerateServerChanMessage
-func GenerateServerChanMessage(ctx context.Context, funds []Fund, minRiseNum float64, maxFallNum float64) (title string, body string, shouldSend bool) {
-	var elements []string
-	var content string
-	var fallCount int
-	for _, fund := range funds {
-		var status string
-		// 涨跌幅度超出设定值
-		if fund.Gszzl > 0 && fund.Gszzl >= minRiseNum {
-			status = "涨"
-		} else if fund.Gszzl < 0 && fund.Gszzl <= maxFallNum {
-			status = "跌"
-			fallCount++
-		} else {
-			status = "-"
-		}
-		element := `|` + status +
-			`|` + fund.Name +
-			`|` + strconv.FormatFloat(fund.Gszzl, 'f', -1, 64) +
-			`|` + strconv.FormatFloat(fund.Gsz, 'f', -1, 64) +
-			`|` + strconv.FormatFloat(fund.Dwjz, 'f', -1, 64) +
-			`|` + fund.Gztime +
-			`|`
-		elements = append(elements, element)
+// notifiersFromConfig builds every notifier configured under nc, keyed
+// by the name used in WatchGroup.Notifiers.
+func notifiersFromConfig(nc config.NotifiersConfig) map[string]notifier.Notifier {
+	notifiers := make(map[string]notifier.Notifier)
+	if e := nc.Email; e != nil {
+		notifiers["email"] = notifier.NewEmailNotifier(e.SMTPHost, e.SMTPPort, e.From, e.Password, e.To)
 	}
-	if len(elements) > 0 {
-		title = fmt.Sprintf("基金涨跌监控_%s_%d跌", time.Now().In(timeLocationCST).Format("2006-01-02"), fallCount)
-		content = strings.Join(elements, "\n")
-		body = `
-|状态|基金名称|估算涨幅|当前估算净值|昨日单位净值|估算时间|
-| -- | -- | -- | -- | -- | -- |
-` + content
-
-		return title, body, true
+	if s := nc.ServerChan; s != nil {
+		notifiers["serverchan"] = notifier.NewServerChanNotifier(s.Key)
+	}
+	if s := nc.ServerChanTurbo; s != nil {
+		notifiers["serverchan_turbo"] = notifier.NewServerChanTurboNotifier(s.Key)
+	}
+	if w := nc.WeCom; w != nil {
+		notifiers["wecom"] = notifier.NewWeComNotifier(w.Key)
+	}
+	if d := nc.DingTalk; d != nil {
+		notifiers["dingtalk"] = notifier.NewDingTalkNotifier(d.AccessToken, d.Secret)
+	}
+	if t := nc.Telegram; t != nil {
+		notifiers["telegram"] = notifier.NewTelegramNotifier(t.BotToken, t.ChatID)
+	}
+	if b := nc.Bark; b != nil {
+		notifiers["bark"] = notifier.NewBarkNotifier(b.DeviceKey, b.Server)
 	}
-	return "", "", false
+	return notifiers
 }
 
-func SendServerChan(ctx context.Context, title string, body string, serverChanKey string) (err error) {
-	if title == "" {
-		err = errors.Errorf(err, "empty title")
-		return
+// registryForGroup narrows the full notifier set down to the ones a
+// WatchGroup asked for; an empty selection means "all configured".
+func registryForGroup(all map[string]notifier.Notifier, group config.WatchGroup) *notifier.Registry {
+	if len(group.Notifiers) == 0 {
+		selected := make([]notifier.Notifier, 0, len(all))
+		for _, n := range all {
+			selected = append(selected, n)
+		}
+		return notifier.NewRegistry(selected...)
 	}
-	if serverChanKey == "" {
-		err = errors.Errorf(err, "empty serverChanKey")
-		return
+	selected := make([]notifier.Notifier, 0, len(group.Notifiers))
+	for _, name := range group.Notifiers {
+		if n, ok := all[name]; ok {
+			selected = append(selected, n)
+		}
 	}
-	if len(title) > 256 {
-		title = title[:256]
+	return notifier.NewRegistry(selected...)
+}
+
+// inQuietHours reports whether now falls inside any of the group's
+// quiet-hour windows. Windows may wrap midnight (e.g. 22:00-07:00).
+func inQuietHours(now time.Time, windows []config.QuietHour) bool {
+	clock := now.In(timeLocationCST).Format("15:04")
+	for _, w := range windows {
+		if w.Start == "" || w.End == "" {
+			continue
+		}
+		if w.Start <= w.End {
+			if clock >= w.Start && clock < w.End {
+				return true
+			}
+		} else if clock >= w.Start || clock < w.End {
+			return true
+		}
 	}
-	param := url.Values{}
-	param.Set("text", title)
-	param.Set("desp", body)
-	reqURL := fmt.Sprintf("https://sc.ftqq.com/%s.send", serverChanKey)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, reqURL, strings.NewReader(param.Encode()))
+	return false
+}
+
+// runGroup fetches and notifies a single watch group. onFunds, if
+// non-nil, is called with every fetched fund before the quiet-hours
+// check so callers (e.g. the -serve /funds snapshot) still see data
+// collected during a suppressed notification.
+func runGroup(ctx context.Context, group config.WatchGroup, registry *notifier.Registry, overrides map[string]config.FundOverride, onFunds func([]Fund)) error {
+	log.Printf("watch group:%s fundCodes:%v", group.Name, group.FundCodes)
+	funds, err := FetchFunds(ctx, group.FundCodes)
 	if err != nil {
-		err = errors.Errorf(err, "http newRequest")
-		return
+		return errors.Errorf(err, "group:%s", group.Name)
 	}
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	resp, err := httpClient.Do(req)
-	if err != nil {
-		err = errors.Errorf(err, "http get")
-		return
+	if onFunds != nil {
+		onFunds(funds)
 	}
-	defer func() {
-		_ = resp.Body.Close()
-	}()
-	if resp.StatusCode != http.StatusOK {
-		err = errors.Errorf(err, "server return %d", resp.StatusCode)
-		return
+	if inQuietHours(time.Now(), group.QuietHours) {
+		log.Printf("watch group:%s is in quiet hours, skip", group.Name)
+		return nil
+	}
+	report := buildReport(funds, group.MinRise, group.MaxFall, overrides)
+	if err := registry.Send(ctx, report); err != nil {
+		return errors.Errorf(err, "group:%s", group.Name)
 	}
-	respBody, _ := ioutil.ReadAll(resp.Body)
-	log.Printf("respBody:%s", respBody)
 	return nil
 }
 
@@ -301,71 +278,78 @@ func init() {
 func main() {
 	// log
 	log.SetFlags(log.LstdFlags | log.Lshortfile)
-	// config
-	fundCodes := os.Getenv("FOUND_CODES")
-	if fundCodes == "" {
-		fundCodes = "163406,519697,180012,003095,519778"
+	if len(os.Args) > 1 && os.Args[1] == "backfill" {
+		runBackfillCmd(os.Args[2:])
+		return
 	}
-	log.Printf("fundCodes:%+v", fundCodes)
-	smtpHost := os.Getenv("SMTP_HOST")
-	emailName := os.Getenv("EMAIL_NAME")
-	emailPassword := os.Getenv("EMAIL_PASSWORD")
-	emailTo := os.Getenv("EMAIL_TO")
-	if emailTo == "" {
-		emailTo = emailName
+	configFlag := flag.String("config", "", "path to the YAML config file (defaults to VLIGHT_CONFIG)")
+	daemonFlag := flag.Bool("daemon", false, "run as a long-lived scheduler instead of a one-shot poll (requires -config)")
+	holidaysFlag := flag.String("holidays", "", "path to a holiday override file (add/remove dates from the embedded table)")
+	serveFlag := flag.Bool("serve", false, "mount /metrics, /healthz, /readyz and /funds for this one-shot run")
+	listenFlag := flag.String("listen", ":9100", "listen address for -serve (-daemon uses the config's listen_addr)")
+	flag.Parse()
+
+	if *daemonFlag {
+		configPath := config.ResolvePath(*configFlag)
+		if configPath == "" {
+			log.Fatal("-daemon requires -config (or VLIGHT_CONFIG)")
+		}
+		if err := runDaemon(context.Background(), configPath, *holidaysFlag); err != nil {
+			log.Fatal(err)
+		}
+		return
 	}
-	log.Printf("emailTo:%+v", emailTo)
-	serverChanKey := os.Getenv("SCKEY")
-	// start do
+
+	var srv *httpserver.Server
+	if *serveFlag {
+		srv = httpserver.New(*listenFlag)
+		go func() {
+			if err := srv.Start(); err != nil {
+				log.Printf("[E]httpserver stopped, err:%s", err)
+			}
+		}()
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), time.Minute*2)
 	defer cancel()
-	// fetch funds data
-	fundResult, err := FetchFunds(ctx, strings.Split(fundCodes, ","))
-	if err != nil {
-		log.Fatalf("failed to fetch funds, err:%s", err)
-		return
-	}
-	// judge
-	const minRiseNum, maxFallNum = 1, -0.8
-	errGroup, ctx := errgroup.WithContext(ctx)
-	// notify via email
-	errGroup.Go(func() error {
-		content, shouldSend := GenerateEmailHTML(ctx, fundResult, minRiseNum, maxFallNum)
-		log.Printf("shouldSend email:%v", shouldSend)
-		if smtpHost == "" || emailName == "" || emailPassword == "" || emailTo == "" {
-			shouldSend = false
-			log.Printf("shouldSend email:%v", shouldSend)
+	configPath := config.ResolvePath(*configFlag)
+	if configPath == "" {
+		// legacy one-shot path: single group from FOUND_CODES/SMTP_*/SCKEY
+		fundCodes := os.Getenv("FOUND_CODES")
+		if fundCodes == "" {
+			fundCodes = "163406,519697,180012,003095,519778"
 		}
-		if !shouldSend {
-			return nil
-		}
-		err = SendEmail(ctx, content, smtpHost, emailName, emailPassword, emailTo)
+		log.Printf("fundCodes:%+v", fundCodes)
+		const minRiseNum, maxFallNum = 1, -0.8
+		fundResult, err := FetchFunds(ctx, strings.Split(fundCodes, ","))
 		if err != nil {
-			log.Printf("[E]failed to send email, err:%s", err)
-			return err
+			log.Fatalf("failed to fetch funds, err:%s", err)
 		}
-		return nil
-	})
-	// notify via serverChan
-	errGroup.Go(func() error {
-		title, body, shouldSend := GenerateServerChanMessage(ctx, fundResult, minRiseNum, maxFallNum)
-		log.Printf("shouldSend serverChan :%v", shouldSend)
-		if serverChanKey == "" {
-			shouldSend = false
-			log.Printf("shouldSend serverChan:%v", shouldSend)
+		if srv != nil {
+			srv.SetFunds(fundResult)
+			srv.SetReady(true)
 		}
-		if !shouldSend {
-			return nil
+		report := buildReport(fundResult, minRiseNum, maxFallNum, nil)
+		if err := legacyRegistry().Send(ctx, report); err != nil {
+			log.Fatal(err)
 		}
-		// notify via email
-		err = SendServerChan(ctx, title, body, serverChanKey)
-		if err != nil {
-			log.Printf("[E]failed to send serverChan, err:%s", err)
-			return err
+		return
+	}
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		log.Fatalf("failed to load config, path:%s, err:%s", configPath, err)
+	}
+	setActiveProvider(cfg.Providers)
+	allNotifiers := notifiersFromConfig(cfg.Notifiers)
+	var allFunds []Fund
+	for _, group := range cfg.WatchGroups {
+		onFunds := func(funds []Fund) { allFunds = append(allFunds, funds...) }
+		if err := runGroup(ctx, group, registryForGroup(allNotifiers, group), cfg.FundOverrides, onFunds); err != nil {
+			log.Printf("[E]%s", err)
 		}
-		return nil
-	})
-	if err := errGroup.Wait(); err != nil {
-		log.Fatal(err)
+	}
+	if srv != nil {
+		srv.SetFunds(allFunds)
+		srv.SetReady(true)
 	}
 }
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hanjm/vlight/config"
+)
+
+func TestInQuietHours(t *testing.T) {
+	at := func(clock string) time.Time {
+		ts, err := time.ParseInLocation("15:04", clock, timeLocationCST)
+		if err != nil {
+			t.Fatalf("parse %q: %s", clock, err)
+		}
+		return ts
+	}
+	cases := []struct {
+		name   string
+		now    string
+		window []config.QuietHour
+		quiet  bool
+	}{
+		{
+			name:   "不跨午夜,窗口内",
+			now:    "23:00",
+			window: []config.QuietHour{{Start: "22:00", End: "23:30"}},
+			quiet:  true,
+		},
+		{
+			name:   "不跨午夜,窗口外",
+			now:    "21:00",
+			window: []config.QuietHour{{Start: "22:00", End: "23:30"}},
+			quiet:  false,
+		},
+		{
+			name:   "跨午夜,凌晨仍在窗口内",
+			now:    "02:00",
+			window: []config.QuietHour{{Start: "22:00", End: "07:00"}},
+			quiet:  true,
+		},
+		{
+			name:   "跨午夜,窗口外",
+			now:    "12:00",
+			window: []config.QuietHour{{Start: "22:00", End: "07:00"}},
+			quiet:  false,
+		},
+		{
+			name:   "空窗口忽略",
+			now:    "23:00",
+			window: []config.QuietHour{{Start: "", End: ""}},
+			quiet:  false,
+		},
+		{
+			name:   "没有配置窗口",
+			now:    "23:00",
+			window: nil,
+			quiet:  false,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := inQuietHours(at(c.now), c.window)
+			if got != c.quiet {
+				t.Fatalf("inQuietHours(%s) = %v, want %v", c.now, got, c.quiet)
+			}
+		})
+	}
+}
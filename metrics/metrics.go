@@ -0,0 +1,40 @@
+// Package metrics holds the Prometheus collectors shared across vlight
+// so fetches and notifier sends stay observable regardless of which
+// package performs them. httpserver exposes these at /metrics.
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	// FetchTotal counts FetchFund calls by fund code and result
+	// ("ok" or "error").
+	FetchTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "vlight_fetch_total",
+		Help: "Total number of fund valuation fetches.",
+	}, []string{"code", "result"})
+
+	// FetchDuration observes how long each FetchFund call takes.
+	FetchDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "vlight_fetch_duration_seconds",
+		Help:    "Duration of fund valuation fetches in seconds.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"code"})
+
+	// FundGszzl is the most recent estimated growth rate (Gszzl) seen
+	// per fund, so it can be plotted directly in Grafana.
+	FundGszzl = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "vlight_fund_gszzl",
+		Help: "Most recent estimated growth rate (%) per fund.",
+	}, []string{"code", "name"})
+
+	// NotifyTotal counts notifier sends by channel name and result
+	// ("ok" or "error").
+	NotifyTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "vlight_notify_total",
+		Help: "Total number of notifier send attempts.",
+	}, []string{"channel", "result"})
+)
+
+func init() {
+	prometheus.MustRegister(FetchTotal, FetchDuration, FundGszzl, NotifyTotal)
+}
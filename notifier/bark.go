@@ -0,0 +1,76 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+
+	"github.com/hanjm/errors"
+)
+
+// defaultBarkServer is the public Bark push server used when Server is
+// left empty.
+const defaultBarkServer = "https://api.day.app"
+
+// BarkNotifier sends the markdown report as a Bark push notification
+// (https://bark.day.app). Server can be overridden to point at a
+// self-hosted Bark server.
+type BarkNotifier struct {
+	DeviceKey string
+	Server    string
+}
+
+// NewBarkNotifier builds a BarkNotifier for the given device key. server
+// may be empty to use the public Bark server.
+func NewBarkNotifier(deviceKey, server string) *BarkNotifier {
+	if server == "" {
+		server = defaultBarkServer
+	}
+	return &BarkNotifier{DeviceKey: deviceKey, Server: server}
+}
+
+// Name implements Notifier.
+func (b *BarkNotifier) Name() string { return "bark" }
+
+type barkPushPayload struct {
+	DeviceKey string `json:"device_key"`
+	Title     string `json:"title"`
+	Body      string `json:"body"`
+}
+
+// Send implements Notifier.
+func (b *BarkNotifier) Send(ctx context.Context, report Report) error {
+	title, body, shouldSend := renderMarkdown(report)
+	if !shouldSend {
+		return nil
+	}
+	// 用JSON POST到/push而不是把report塞进GET的URL路径,避免超出URL长度限制
+	payload := barkPushPayload{DeviceKey: b.DeviceKey, Title: title, Body: body}
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Errorf(err, "marshal payload")
+	}
+	reqURL := b.Server + "/push"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, reqURL, bytes.NewReader(payloadBytes))
+	if err != nil {
+		// reqURL本身不含device_key,但payload里有,整体上还是不打印body
+		return errors.Errorf(err, "new request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return errors.Errorf(err, "do request")
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf(nil, "server return %d", resp.StatusCode)
+	}
+	respBody, _ := ioutil.ReadAll(resp.Body)
+	log.Printf("bark respBody:%s", respBody)
+	return nil
+}
@@ -0,0 +1,94 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/hanjm/errors"
+)
+
+// DingTalkNotifier sends the markdown report to a DingTalk ("钉钉")
+// custom robot webhook. When Secret is set, requests are signed with
+// HMAC-SHA256 over "timestamp\nsecret" as DingTalk's signed-robot mode
+// requires.
+type DingTalkNotifier struct {
+	AccessToken string
+	Secret      string
+}
+
+// NewDingTalkNotifier builds a DingTalkNotifier. secret may be empty if
+// the robot's security settings don't require signing.
+func NewDingTalkNotifier(accessToken, secret string) *DingTalkNotifier {
+	return &DingTalkNotifier{AccessToken: accessToken, Secret: secret}
+}
+
+// Name implements Notifier.
+func (d *DingTalkNotifier) Name() string { return "dingtalk" }
+
+type dingTalkMarkdownPayload struct {
+	MsgType  string `json:"msgtype"`
+	Markdown struct {
+		Title string `json:"title"`
+		Text  string `json:"text"`
+	} `json:"markdown"`
+}
+
+// sign returns the timestamp and base64 HMAC-SHA256 signature DingTalk
+// expects as the `timestamp` and `sign` query parameters.
+func (d *DingTalkNotifier) sign(now time.Time) (timestamp string, sign string) {
+	timestamp = fmt.Sprintf("%d", now.UnixNano()/int64(time.Millisecond))
+	stringToSign := timestamp + "\n" + d.Secret
+	h := hmac.New(sha256.New, []byte(d.Secret))
+	h.Write([]byte(stringToSign))
+	sign = base64.StdEncoding.EncodeToString(h.Sum(nil))
+	return timestamp, sign
+}
+
+// Send implements Notifier.
+func (d *DingTalkNotifier) Send(ctx context.Context, report Report) error {
+	title, body, shouldSend := renderMarkdown(report)
+	if !shouldSend {
+		return nil
+	}
+	payload := dingTalkMarkdownPayload{MsgType: "markdown"}
+	payload.Markdown.Title = title
+	payload.Markdown.Text = body
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Errorf(err, "marshal payload")
+	}
+	reqURL := "https://oapi.dingtalk.com/robot/send?access_token=" + d.AccessToken
+	if d.Secret != "" {
+		timestamp, sign := d.sign(time.Now())
+		reqURL += "&timestamp=" + timestamp + "&sign=" + url.QueryEscape(sign)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, reqURL, bytes.NewReader(payloadBytes))
+	if err != nil {
+		// reqURL里带access_token/sign,不能打进日志
+		return errors.Errorf(err, "new request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return errors.Errorf(err, "do request")
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf(nil, "server return %d", resp.StatusCode)
+	}
+	respBody, _ := ioutil.ReadAll(resp.Body)
+	log.Printf("dingtalk respBody:%s", respBody)
+	return nil
+}
@@ -0,0 +1,48 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hanjm/errors"
+	"gopkg.in/gomail.v2"
+)
+
+// EmailNotifier sends the HTML report over SMTP.
+type EmailNotifier struct {
+	SMTPHost string
+	SMTPPort int
+	From     string
+	Password string
+	To       string
+}
+
+// NewEmailNotifier builds an EmailNotifier. port defaults to 587 when 0.
+func NewEmailNotifier(smtpHost string, port int, from, password, to string) *EmailNotifier {
+	if port == 0 {
+		port = 587
+	}
+	return &EmailNotifier{SMTPHost: smtpHost, SMTPPort: port, From: from, Password: password, To: to}
+}
+
+// Name implements Notifier.
+func (e *EmailNotifier) Name() string { return "email" }
+
+// Send implements Notifier.
+func (e *EmailNotifier) Send(ctx context.Context, report Report) error {
+	content, shouldSend := renderHTML(report)
+	if !shouldSend {
+		return nil
+	}
+	m := gomail.NewMessage()
+	m.SetHeader("From", e.From)
+	m.SetHeader("To", e.To)
+	m.SetHeader("Subject", fmt.Sprintf("基金涨跌监控-%s", time.Now().In(timeLocationCST).Format(time.RFC3339)))
+	m.SetBody("text/html", content)
+	d := gomail.NewDialer(e.SMTPHost, e.SMTPPort, e.From, e.Password)
+	if err := d.DialAndSend(m); err != nil {
+		return errors.Errorf(err, "dial and send, to:%s", e.To)
+	}
+	return nil
+}
@@ -0,0 +1,11 @@
+package notifier
+
+import (
+	"net/http"
+	"time"
+)
+
+// httpClient is shared by every HTTP-based notifier.
+var httpClient = &http.Client{
+	Timeout: time.Minute,
+}
@@ -0,0 +1,93 @@
+// Package notifier fans a single alert Report out to every enabled
+// backend (email, chat bots, ...) concurrently so that adding a new
+// channel only means implementing the Notifier interface, not touching
+// main.
+package notifier
+
+import (
+	"context"
+	"time"
+
+	"github.com/hanjm/errors"
+	"github.com/hanjm/vlight/metrics"
+	"golang.org/x/sync/errgroup"
+)
+
+// FundStatus is one fund's valuation snapshot together with the alert
+// status already decided by the caller (涨/跌/-).
+type FundStatus struct {
+	Name   string
+	Status string
+	Gszzl  float64
+	Gsz    float64
+	Dwjz   float64
+	Gztime string
+}
+
+// Note is an extra annotation attached to a Report, e.g. a strategy
+// rule that fired (which rule, and the prior values that triggered it).
+type Note struct {
+	Title   string
+	Message string
+}
+
+// Report is the payload handed to every Notifier. It is built once per
+// poll and rendered independently by each backend.
+type Report struct {
+	Funds       []FundStatus
+	MinRiseNum  float64
+	MaxFallNum  float64
+	GeneratedAt time.Time
+	// Notes holds extra annotations beyond the daily threshold table,
+	// e.g. strategy alerts (trend, MA cross, new low).
+	Notes []Note
+}
+
+// Notifier is implemented by every alert backend.
+type Notifier interface {
+	// Name returns the unique identifier used in config and logs.
+	Name() string
+	// Send delivers the report. It should return nil without doing
+	// anything if the report has nothing worth sending.
+	Send(ctx context.Context, report Report) error
+}
+
+// Registry holds the enabled notifiers and fans a Report out to all of
+// them concurrently via errgroup.
+type Registry struct {
+	notifiers []Notifier
+}
+
+// NewRegistry builds a Registry from the given notifiers.
+func NewRegistry(notifiers ...Notifier) *Registry {
+	return &Registry{notifiers: notifiers}
+}
+
+// Send delivers the report to every registered notifier concurrently,
+// returning the first error encountered (if any) after all notifiers
+// have been attempted.
+func (r *Registry) Send(ctx context.Context, report Report) error {
+	if len(r.notifiers) == 0 {
+		return nil
+	}
+	// 不用errgroup.WithContext:它返回的ctx会在第一个notifier出错时被取消,
+	// 导致还在发送中的其他healthy notifier的请求被提前中断。这里每个
+	// notifier都用调用方传入的原始ctx,互不影响。
+	var errGroup errgroup.Group
+	for _, n := range r.notifiers {
+		n := n
+		errGroup.Go(func() error {
+			err := n.Send(ctx, report)
+			result := "ok"
+			if err != nil {
+				result = "error"
+			}
+			metrics.NotifyTotal.WithLabelValues(n.Name(), result).Inc()
+			if err != nil {
+				return errors.Errorf(err, "notifier:%s", n.Name())
+			}
+			return nil
+		})
+	}
+	return errGroup.Wait()
+}
@@ -0,0 +1,62 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hanjm/errors"
+)
+
+// ServerChanNotifier sends the markdown report via the legacy Server酱
+// endpoint (sc.ftqq.com). Kept for users who haven't migrated to Turbo
+// yet; see ServerChanTurboNotifier for the new sctapi.ftqq.com API.
+type ServerChanNotifier struct {
+	Key string
+}
+
+// NewServerChanNotifier builds a ServerChanNotifier for the given key.
+func NewServerChanNotifier(key string) *ServerChanNotifier {
+	return &ServerChanNotifier{Key: key}
+}
+
+// Name implements Notifier.
+func (s *ServerChanNotifier) Name() string { return "serverchan" }
+
+// Send implements Notifier.
+func (s *ServerChanNotifier) Send(ctx context.Context, report Report) error {
+	title, body, shouldSend := renderMarkdown(report)
+	if !shouldSend {
+		return nil
+	}
+	if len(title) > 256 {
+		title = title[:256]
+	}
+	param := url.Values{}
+	param.Set("text", title)
+	param.Set("desp", body)
+	reqURL := fmt.Sprintf("https://sc.ftqq.com/%s.send", s.Key)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, reqURL, strings.NewReader(param.Encode()))
+	if err != nil {
+		// reqURL里带key,不能打进日志
+		return errors.Errorf(err, "new request")
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return errors.Errorf(err, "do request")
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf(nil, "server return %d", resp.StatusCode)
+	}
+	respBody, _ := ioutil.ReadAll(resp.Body)
+	log.Printf("serverchan respBody:%s", respBody)
+	return nil
+}
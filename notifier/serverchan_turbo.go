@@ -0,0 +1,61 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hanjm/errors"
+)
+
+// ServerChanTurboNotifier sends the markdown report via Server酱 Turbo
+// (sctapi.ftqq.com), the successor to the legacy sc.ftqq.com endpoint.
+type ServerChanTurboNotifier struct {
+	Key string
+}
+
+// NewServerChanTurboNotifier builds a ServerChanTurboNotifier for the given key.
+func NewServerChanTurboNotifier(key string) *ServerChanTurboNotifier {
+	return &ServerChanTurboNotifier{Key: key}
+}
+
+// Name implements Notifier.
+func (s *ServerChanTurboNotifier) Name() string { return "serverchan_turbo" }
+
+// Send implements Notifier.
+func (s *ServerChanTurboNotifier) Send(ctx context.Context, report Report) error {
+	title, body, shouldSend := renderMarkdown(report)
+	if !shouldSend {
+		return nil
+	}
+	if len(title) > 256 {
+		title = title[:256]
+	}
+	param := url.Values{}
+	param.Set("title", title)
+	param.Set("desp", body)
+	reqURL := fmt.Sprintf("https://sctapi.ftqq.com/%s.send", s.Key)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, reqURL, strings.NewReader(param.Encode()))
+	if err != nil {
+		// reqURL里带key,不能打进日志
+		return errors.Errorf(err, "new request")
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return errors.Errorf(err, "do request")
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf(nil, "server return %d", resp.StatusCode)
+	}
+	respBody, _ := ioutil.ReadAll(resp.Body)
+	log.Printf("serverchan_turbo respBody:%s", respBody)
+	return nil
+}
@@ -0,0 +1,60 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hanjm/errors"
+)
+
+// TelegramNotifier sends the markdown report via the Telegram Bot API's
+// sendMessage method.
+type TelegramNotifier struct {
+	BotToken string
+	ChatID   string
+}
+
+// NewTelegramNotifier builds a TelegramNotifier for the given bot token
+// and destination chat id.
+func NewTelegramNotifier(botToken, chatID string) *TelegramNotifier {
+	return &TelegramNotifier{BotToken: botToken, ChatID: chatID}
+}
+
+// Name implements Notifier.
+func (t *TelegramNotifier) Name() string { return "telegram" }
+
+// Send implements Notifier.
+func (t *TelegramNotifier) Send(ctx context.Context, report Report) error {
+	title, body, shouldSend := renderMarkdown(report)
+	if !shouldSend {
+		return nil
+	}
+	param := url.Values{}
+	param.Set("chat_id", t.ChatID)
+	param.Set("text", title+"\n"+body)
+	reqURL := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", t.BotToken)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, reqURL, strings.NewReader(param.Encode()))
+	if err != nil {
+		// reqURL里带bot_token,不能打进日志
+		return errors.Errorf(err, "new request")
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return errors.Errorf(err, "do request")
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf(nil, "server return %d", resp.StatusCode)
+	}
+	respBody, _ := ioutil.ReadAll(resp.Body)
+	log.Printf("telegram respBody:%s", respBody)
+	return nil
+}
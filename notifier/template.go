@@ -0,0 +1,140 @@
+package notifier
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var timeLocationCST = time.FixedZone("CST", 28800)
+
+// renderHTML builds the HTML table used by the email notifier. It
+// mirrors the markdown table built by renderMarkdown so the two stay in
+// sync when a column is added.
+func renderHTML(report Report) (html string, shouldSend bool) {
+	var elements []string
+	for _, fund := range report.Funds {
+		element := `
+            <tr>
+              <td width="50" align="center">` + fund.Status + `</td>
+              <td width="50" align="center">` + fund.Name + `</td>
+              <td width="50" align="center">` + strconv.FormatFloat(fund.Gszzl, 'f', -1, 64) + `%</td>
+              <td width="50" align="center">` + strconv.FormatFloat(fund.Gsz, 'f', -1, 64) + `</td>
+              <td width="50" align="center">` + strconv.FormatFloat(fund.Dwjz, 'f', -1, 64) + `</td>
+              <td width="50" align="center">` + fund.Gztime + `</td>
+            </tr>
+			`
+		elements = append(elements, element)
+	}
+	if len(elements) == 0 {
+		return "", false
+	}
+	content := strings.Join(elements, "\n")
+	html = `
+		</html>
+			<head>
+				<meta http-equiv="Content-Type" content="text/html; charset=utf-8" />
+			</head>
+        <body>
+			<div id="container">
+				<p>基金涨跌监控:</p>
+				<div id="content">
+					<table width="30%" border="1" cellspacing="0" cellpadding="0">
+						<tr>
+						  <td width="50" align="center">状态</td>
+						  <td width="100" align="center">基金名称</td>
+						  <td width="50" align="center">估算涨幅</td>
+						  <td width="50" align="center">当前估算净值</td>
+						  <td width="50" align="center">昨日单位净值</td>
+						  <td width="50" align="center">估算时间</td>
+						</tr>` + content + `
+					</table>
+				</div>` + renderHTMLNotes(report.Notes) + `
+            	</div>
+            </div>
+            </body>
+        </html>`
+	return html, true
+}
+
+// renderHTMLNotes renders strategy alerts as a bullet list under the
+// threshold table, or "" if there are none.
+func renderHTMLNotes(notes []Note) string {
+	if len(notes) == 0 {
+		return ""
+	}
+	var items []string
+	for _, note := range notes {
+		items = append(items, `<li>`+note.Title+`：`+note.Message+`</li>`)
+	}
+	return `<div id="notes"><p>策略提醒:</p><ul>` + strings.Join(items, "\n") + `</ul></div>`
+}
+
+// renderMarkdown builds the markdown table shared by every chat-bot
+// notifier (WeChat Work, DingTalk, Telegram, Bark, Server酱).
+func renderMarkdown(report Report) (title string, body string, shouldSend bool) {
+	var elements []string
+	var fallCount int
+	for _, fund := range report.Funds {
+		if fund.Status == "跌" {
+			fallCount++
+		}
+		elements = append(elements, `|`+fund.Status+
+			`|`+fund.Name+
+			`|`+strconv.FormatFloat(fund.Gszzl, 'f', -1, 64)+
+			`|`+strconv.FormatFloat(fund.Gsz, 'f', -1, 64)+
+			`|`+strconv.FormatFloat(fund.Dwjz, 'f', -1, 64)+
+			`|`+fund.Gztime+
+			`|`)
+	}
+	if len(elements) == 0 {
+		return "", "", false
+	}
+	title = fmt.Sprintf("基金涨跌监控_%s_%d跌", time.Now().In(timeLocationCST).Format("2006-01-02"), fallCount)
+	body = `
+|状态|基金名称|估算涨幅|当前估算净值|昨日单位净值|估算时间|
+| -- | -- | -- | -- | -- | -- |
+` + strings.Join(elements, "\n") + renderMarkdownNotes(report.Notes)
+	return title, body, true
+}
+
+// renderMarkdownNotes renders strategy alerts as a markdown list
+// appended after the threshold table, or "" if there are none.
+func renderMarkdownNotes(notes []Note) string {
+	if len(notes) == 0 {
+		return ""
+	}
+	var lines []string
+	for _, note := range notes {
+		lines = append(lines, "- **"+note.Title+"**："+note.Message)
+	}
+	return "\n\n策略提醒:\n" + strings.Join(lines, "\n")
+}
+
+// NewFundStatus classifies a fund into the 涨/跌/- status used by every
+// renderer. With holdCost <= 0 the classification is the daily Gszzl
+// against the report thresholds, same as always. With holdCost > 0 (a
+// configured FundOverride.HoldCost) it classifies by the unrealized P&L
+// % against that cost instead, so a fund bought well below its current
+// estimate still flags 涨 even on a flat or red day.
+func NewFundStatus(name string, gszzl, gsz, dwjz float64, gztime string, minRiseNum, maxFallNum, holdCost float64) FundStatus {
+	classifyBy := gszzl
+	if holdCost > 0 {
+		classifyBy = (gsz - holdCost) / holdCost * 100
+	}
+	status := "-"
+	if classifyBy > 0 && classifyBy >= minRiseNum {
+		status = "涨"
+	} else if classifyBy < 0 && classifyBy <= maxFallNum {
+		status = "跌"
+	}
+	return FundStatus{
+		Name:   name,
+		Status: status,
+		Gszzl:  gszzl,
+		Gsz:    gsz,
+		Dwjz:   dwjz,
+		Gztime: gztime,
+	}
+}
@@ -0,0 +1,68 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+
+	"github.com/hanjm/errors"
+)
+
+// WeComNotifier sends the markdown report to a WeChat Work ("企业微信")
+// group bot webhook.
+type WeComNotifier struct {
+	Key string
+}
+
+// NewWeComNotifier builds a WeComNotifier for the given webhook key.
+func NewWeComNotifier(key string) *WeComNotifier {
+	return &WeComNotifier{Key: key}
+}
+
+// Name implements Notifier.
+func (w *WeComNotifier) Name() string { return "wecom" }
+
+type weComMarkdownPayload struct {
+	MsgType  string `json:"msgtype"`
+	Markdown struct {
+		Content string `json:"content"`
+	} `json:"markdown"`
+}
+
+// Send implements Notifier.
+func (w *WeComNotifier) Send(ctx context.Context, report Report) error {
+	title, body, shouldSend := renderMarkdown(report)
+	if !shouldSend {
+		return nil
+	}
+	payload := weComMarkdownPayload{MsgType: "markdown"}
+	payload.Markdown.Content = fmt.Sprintf("**%s**\n%s", title, body)
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Errorf(err, "marshal payload")
+	}
+	reqURL := "https://qyapi.weixin.qq.com/cgi-bin/webhook/send?key=" + w.Key
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, reqURL, bytes.NewReader(payloadBytes))
+	if err != nil {
+		// reqURL里带key,不能打进日志
+		return errors.Errorf(err, "new request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return errors.Errorf(err, "do request")
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf(nil, "server return %d", resp.StatusCode)
+	}
+	respBody, _ := ioutil.ReadAll(resp.Body)
+	log.Printf("wecom respBody:%s", respBody)
+	return nil
+}
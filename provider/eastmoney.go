@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/hanjm/errors"
+)
+
+var (
+	eastMoneyBodyPrefix = []byte("jsonpgz(")
+	eastMoneyBodySuffix = []byte(");")
+)
+
+// EastMoneyProvider fetches from fundgz.1234567.com.cn, vlight's
+// original (and default) data source.
+type EastMoneyProvider struct {
+	httpClient *http.Client
+}
+
+// NewEastMoneyProvider builds an EastMoneyProvider.
+func NewEastMoneyProvider() *EastMoneyProvider {
+	return &EastMoneyProvider{httpClient: &http.Client{Timeout: time.Minute}}
+}
+
+// Name implements Provider.
+func (p *EastMoneyProvider) Name() string { return "eastmoney" }
+
+// Fetch implements Provider.
+func (p *EastMoneyProvider) Fetch(ctx context.Context, code string) (fund Fund, err error) {
+	reqURL := "http://fundgz.1234567.com.cn/js/" + code + ".js"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		err = errors.Errorf(err, "new request, url:%s", reqURL)
+		return
+	}
+	// 设置一个正常浏览器的ua
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_14_6) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/78.0.3904.70 Safari/537.36")
+	log.Printf("request url:%s", reqURL)
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		err = errors.Errorf(err, "do request, url:%s", reqURL)
+		return
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		err = errors.Errorf(err, "read body")
+		return
+	}
+	// 处理body
+	body = bytes.TrimPrefix(body, eastMoneyBodyPrefix)
+	body = bytes.TrimSuffix(body, eastMoneyBodySuffix)
+	if len(body) == 0 {
+		err = errors.Errorf(nil, "empty body outside trading hours, code:%s", code)
+		return
+	}
+	var raw struct {
+		FundCode string `json:"fundcode"`
+		Name     string `json:"name"`
+		JzRq     string `json:"jzrq"`
+		Dwjz     string `json:"dwjz"`
+		Gsz      string `json:"gsz"`
+		Gszzl    string `json:"gszzl"`
+		Gztime   string `json:"gztime"`
+	}
+	if err = json.Unmarshal(body, &raw); err != nil {
+		err = errors.Errorf(err, "unmarshal, body:%s", body)
+		return
+	}
+	fund, err = normalizeRaw(raw.FundCode, raw.Name, raw.JzRq, raw.Dwjz, raw.Gsz, raw.Gszzl, raw.Gztime)
+	if err != nil {
+		return Fund{}, errors.Errorf(err, "normalize, code:%s", code)
+	}
+	log.Printf("funds:%+v", fund)
+	return fund, nil
+}
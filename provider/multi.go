@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/hanjm/errors"
+)
+
+// DefaultProviderTimeout bounds how long MultiProvider waits on a single
+// provider before moving on to the next one.
+const DefaultProviderTimeout = 10 * time.Second
+
+// MultiProvider tries a list of providers in order, falling through to
+// the next on error (or timeout) until one succeeds. It only surfaces an
+// error once every provider has failed.
+type MultiProvider struct {
+	providers []Provider
+	timeout   time.Duration
+}
+
+// NewMultiProvider builds a MultiProvider trying providers in the given
+// order, each bounded by timeout. A non-positive timeout falls back to
+// DefaultProviderTimeout.
+func NewMultiProvider(providers []Provider, timeout time.Duration) *MultiProvider {
+	if timeout <= 0 {
+		timeout = DefaultProviderTimeout
+	}
+	return &MultiProvider{providers: providers, timeout: timeout}
+}
+
+// Name implements Provider.
+func (p *MultiProvider) Name() string { return "multi" }
+
+// Fetch implements Provider, trying each underlying provider in order
+// until one succeeds.
+func (p *MultiProvider) Fetch(ctx context.Context, code string) (Fund, error) {
+	var lastErr error
+	for _, sub := range p.providers {
+		fund, err := p.fetchOne(ctx, sub, code)
+		if err == nil {
+			return fund, nil
+		}
+		log.Printf("provider:%s failed, code:%s, err:%s", sub.Name(), code, err)
+		lastErr = err
+	}
+	if lastErr == nil {
+		return Fund{}, errors.Errorf(nil, "no providers configured, code:%s", code)
+	}
+	return Fund{}, errors.Errorf(lastErr, "all providers failed, code:%s", code)
+}
+
+func (p *MultiProvider) fetchOne(ctx context.Context, sub Provider, code string) (Fund, error) {
+	subCtx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	return sub.Fetch(subCtx, code)
+}
@@ -0,0 +1,34 @@
+// Package provider abstracts fetching a single fund's valuation behind
+// a common interface so vlight isn't hard-coded to one flaky upstream
+// (fundgz.1234567.com.cn silently returns empty `jsonpgz();` outside
+// trading hours, and is blocked in some regions).
+package provider
+
+import "context"
+
+// Fund is one provider's normalized view of a fund's valuation,
+// regardless of which upstream it came from.
+type Fund struct {
+	// 基金代码
+	FundCode string `json:"fundcode"`
+	// 基金名称
+	Name string `json:"name"`
+	// 截止日期
+	JzRq string `json:"jzrq"`
+	// (昨日)单位净值
+	Dwjz float64 `json:"dwjz,string"`
+	// (当前)估算净值
+	Gsz float64 `json:"gsz,string"`
+	// 估算增长率
+	Gszzl float64 `json:"gszzl,string"`
+	// 估值时间
+	Gztime string `json:"gztime"`
+}
+
+// Provider fetches a single fund's valuation from one upstream source.
+type Provider interface {
+	// Name identifies the provider in config and logs.
+	Name() string
+	// Fetch fetches code's current valuation.
+	Fetch(ctx context.Context, code string) (Fund, error)
+}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hanjm/errors"
+)
+
+// SinaProvider fetches from Sina's fund estimate endpoint
+// (hq.sinajs.cn), a second alternative to EastMoney.
+type SinaProvider struct {
+	httpClient *http.Client
+}
+
+// NewSinaProvider builds a SinaProvider.
+func NewSinaProvider() *SinaProvider {
+	return &SinaProvider{httpClient: &http.Client{Timeout: time.Minute}}
+}
+
+// Name implements Provider.
+func (p *SinaProvider) Name() string { return "sina" }
+
+// Fetch implements Provider.
+func (p *SinaProvider) Fetch(ctx context.Context, code string) (fund Fund, err error) {
+	reqURL := fmt.Sprintf("https://hq.sinajs.cn/list=fu_%s", code)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		err = errors.Errorf(err, "new request, url:%s", reqURL)
+		return
+	}
+	// sina风控要求带Referer
+	req.Header.Set("Referer", "https://finance.sina.com.cn")
+	log.Printf("request url:%s", reqURL)
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		err = errors.Errorf(err, "do request, url:%s", reqURL)
+		return
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		err = errors.Errorf(err, "read body")
+		return
+	}
+	// 响应形如: var hq_str_fu_160119="诺安成长混合,2024-01-02 15:00:00,1.2345,1.2400,0.45,...";
+	start := strings.IndexByte(string(body), '"')
+	end := strings.LastIndexByte(string(body), '"')
+	if start < 0 || end <= start {
+		err = errors.Errorf(nil, "unexpected format, body:%s", body)
+		return
+	}
+	fields := strings.Split(string(body)[start+1:end], ",")
+	if len(fields) < 5 {
+		err = errors.Errorf(nil, "unexpected format, body:%s", body)
+		return
+	}
+	fund, err = normalizeRaw(code, fields[0], "", fields[2], fields[3], fields[4], fields[1])
+	if err != nil {
+		return Fund{}, errors.Errorf(err, "normalize, code:%s", code)
+	}
+	log.Printf("funds:%+v", fund)
+	return fund, nil
+}
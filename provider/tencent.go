@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/hanjm/errors"
+)
+
+// TencentProvider fetches from Tencent's fund estimate endpoint
+// (web.ifzq.gtimg.cn), an alternative to EastMoney for when it's
+// flaky or blocked.
+type TencentProvider struct {
+	httpClient *http.Client
+}
+
+// NewTencentProvider builds a TencentProvider.
+func NewTencentProvider() *TencentProvider {
+	return &TencentProvider{httpClient: &http.Client{Timeout: time.Minute}}
+}
+
+// Name implements Provider.
+func (p *TencentProvider) Name() string { return "tencent" }
+
+type tencentData struct {
+	Name   string `json:"name"`
+	Jzrq   string `json:"jzrq"`
+	Dwjz   string `json:"dwjz"`
+	Gsz    string `json:"gsz"`
+	Gszzl  string `json:"gszzl"`
+	Gztime string `json:"gztime"`
+}
+
+// Fetch implements Provider.
+func (p *TencentProvider) Fetch(ctx context.Context, code string) (fund Fund, err error) {
+	reqURL := fmt.Sprintf("https://web.ifzq.gtimg.cn/fund/newfund/fundSsgz/getSsgz?app=web&symbol=%s", code)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		err = errors.Errorf(err, "new request, url:%s", reqURL)
+		return
+	}
+	log.Printf("request url:%s", reqURL)
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		err = errors.Errorf(err, "do request, url:%s", reqURL)
+		return
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		err = errors.Errorf(err, "read body")
+		return
+	}
+	body = stripJSONP(body)
+	var raw struct {
+		Code int         `json:"code"`
+		Data tencentData `json:"data"`
+	}
+	if err = json.Unmarshal(body, &raw); err != nil {
+		err = errors.Errorf(err, "unmarshal, body:%s", body)
+		return
+	}
+	if raw.Code != 0 {
+		err = errors.Errorf(nil, "upstream returned code:%d, code:%s", raw.Code, code)
+		return
+	}
+	fund, err = normalizeRaw(code, raw.Data.Name, raw.Data.Jzrq, raw.Data.Dwjz, raw.Data.Gsz, raw.Data.Gszzl, raw.Data.Gztime)
+	if err != nil {
+		return Fund{}, errors.Errorf(err, "normalize, code:%s", code)
+	}
+	log.Printf("funds:%+v", fund)
+	return fund, nil
+}
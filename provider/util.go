@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"bytes"
+	"strconv"
+
+	"github.com/hanjm/errors"
+)
+
+// stripJSONP unwraps a `callbackName(...)` JSONP response down to the
+// bytes between the outermost parentheses, for upstreams that don't use
+// EastMoney's fixed jsonpgz(...) wrapper.
+func stripJSONP(body []byte) []byte {
+	start := bytes.IndexByte(body, '(')
+	end := bytes.LastIndexByte(body, ')')
+	if start < 0 || end < 0 || end <= start {
+		return body
+	}
+	return body[start+1 : end]
+}
+
+// normalizeRaw parses each provider's string-encoded fields into a Fund.
+func normalizeRaw(fundCode, name, jzRq, dwjz, gsz, gszzl, gztime string) (Fund, error) {
+	dwjzFloat, err := parseFloat(dwjz)
+	if err != nil {
+		return Fund{}, errors.Errorf(err, "parse dwjz:%s", dwjz)
+	}
+	gszFloat, err := parseFloat(gsz)
+	if err != nil {
+		return Fund{}, errors.Errorf(err, "parse gsz:%s", gsz)
+	}
+	gszzlFloat, err := parseFloat(gszzl)
+	if err != nil {
+		return Fund{}, errors.Errorf(err, "parse gszzl:%s", gszzl)
+	}
+	return Fund{
+		FundCode: fundCode,
+		Name:     name,
+		JzRq:     jzRq,
+		Dwjz:     dwjzFloat,
+		Gsz:      gszFloat,
+		Gszzl:    gszzlFloat,
+		Gztime:   gztime,
+	}, nil
+}
+
+// parseFloat parses s as a float64, treating an empty string as 0 since
+// some upstreams omit gszzl on a fund's very first trading day.
+func parseFloat(s string) (float64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(s, 64)
+}
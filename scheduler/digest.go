@@ -0,0 +1,45 @@
+package scheduler
+
+import "sync"
+
+// WeeklyAggregator accumulates each trading day's Gszzl per fund so the
+// Friday weekly-digest job can report the week's cumulative estimate
+// without needing the persistent history store.
+type WeeklyAggregator struct {
+	mu     sync.Mutex
+	byFund map[string][]float64
+}
+
+// NewWeeklyAggregator builds an empty WeeklyAggregator.
+func NewWeeklyAggregator() *WeeklyAggregator {
+	return &WeeklyAggregator{byFund: make(map[string][]float64)}
+}
+
+// Record appends one trading day's Gszzl for the named fund.
+func (w *WeeklyAggregator) Record(name string, gszzl float64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.byFund[name] = append(w.byFund[name], gszzl)
+}
+
+// Digest sums the recorded Gszzl per fund for the week so far.
+func (w *WeeklyAggregator) Digest() map[string]float64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	sums := make(map[string]float64, len(w.byFund))
+	for name, values := range w.byFund {
+		var sum float64
+		for _, v := range values {
+			sum += v
+		}
+		sums[name] = sum
+	}
+	return sums
+}
+
+// Reset clears the accumulated week so the next week starts empty.
+func (w *WeeklyAggregator) Reset() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.byFund = make(map[string][]float64)
+}
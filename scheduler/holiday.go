@@ -0,0 +1,110 @@
+package scheduler
+
+import (
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/hanjm/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// embeddedHolidays is a small yearly table of Shanghai/Shenzhen stock
+// exchange closure days (New Year, Spring Festival, Qingming, Labour
+// Day, Dragon Boat, Mid-Autumn, National Day). It only needs to cover
+// the years vlight actually runs in; stale or missing years are meant
+// to be patched via the override file rather than by growing this table
+// forever.
+var embeddedHolidays = map[string]bool{
+	// 2025
+	"2025-01-01": true, "2025-01-28": true, "2025-01-29": true, "2025-01-30": true,
+	"2025-01-31": true, "2025-02-03": true, "2025-02-04": true,
+	"2025-04-04": true,
+	"2025-05-01": true, "2025-05-02": true, "2025-05-05": true,
+	"2025-05-31": true, "2025-06-02": true,
+	"2025-10-01": true, "2025-10-02": true, "2025-10-03": true, "2025-10-06": true,
+	"2025-10-07": true, "2025-10-08": true,
+	// 2026
+	"2026-01-01": true, "2026-01-02": true,
+	"2026-02-16": true, "2026-02-17": true, "2026-02-18": true, "2026-02-19": true,
+	"2026-02-20": true, "2026-02-23": true,
+	"2026-04-06": true,
+	"2026-05-01": true,
+	"2026-06-19": true,
+	"2026-09-25": true,
+	"2026-10-01": true, "2026-10-02": true, "2026-10-05": true, "2026-10-06": true,
+	"2026-10-07": true, "2026-10-08": true,
+}
+
+// HolidaySet is the embedded holiday table plus an optional override
+// file, so users don't have to wait for a vlight release to correct a
+// missed or wrong date.
+type HolidaySet struct {
+	dates map[string]bool
+}
+
+// holidayOverride is the YAML schema for the override file: `add` marks
+// extra closure days (e.g. a newly announced one-off holiday), `remove`
+// un-marks a date from the embedded table (e.g. a makeup trading day).
+type holidayOverride struct {
+	Add    []string `yaml:"add"`
+	Remove []string `yaml:"remove"`
+}
+
+// LoadHolidays builds a HolidaySet from the embedded table, applying the
+// override file at path if it's non-empty. A missing override file is
+// not an error; the embedded table is used as-is.
+func LoadHolidays(path string) (*HolidaySet, error) {
+	dates := make(map[string]bool, len(embeddedHolidays))
+	for d := range embeddedHolidays {
+		dates[d] = true
+	}
+	if path == "" {
+		return &HolidaySet{dates: dates}, nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &HolidaySet{dates: dates}, nil
+		}
+		return nil, errors.Errorf(err, "read holiday override, path:%s", path)
+	}
+	var override holidayOverride
+	if err := yaml.Unmarshal(data, &override); err != nil {
+		return nil, errors.Errorf(err, "unmarshal holiday override, path:%s", path)
+	}
+	for _, d := range override.Add {
+		dates[d] = true
+	}
+	for _, d := range override.Remove {
+		delete(dates, d)
+	}
+	return &HolidaySet{dates: dates}, nil
+}
+
+// IsHoliday reports whether t's calendar date is a market holiday.
+func (h *HolidaySet) IsHoliday(t time.Time) bool {
+	if h == nil {
+		return false
+	}
+	return h.dates[t.Format("2006-01-02")]
+}
+
+// IsTradingDay reports whether t is a weekday and not a market holiday.
+func IsTradingDay(t time.Time, holidays *HolidaySet) bool {
+	weekday := t.Weekday()
+	if weekday == time.Saturday || weekday == time.Sunday {
+		return false
+	}
+	return !holidays.IsHoliday(t)
+}
+
+// IsMarketOpen reports whether t falls within the 09:30-15:00 valuation
+// update window on a trading day.
+func IsMarketOpen(t time.Time, holidays *HolidaySet) bool {
+	if !IsTradingDay(t, holidays) {
+		return false
+	}
+	clock := t.Format("15:04")
+	return clock >= "09:30" && clock <= "15:00"
+}
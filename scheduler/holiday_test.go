@@ -0,0 +1,79 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func mustParse(t *testing.T, s string) time.Time {
+	t.Helper()
+	ts, err := time.Parse("2006-01-02 15:04", s)
+	if err != nil {
+		t.Fatalf("parse %q: %s", s, err)
+	}
+	return ts
+}
+
+func TestIsTradingDay(t *testing.T) {
+	holidays, err := LoadHolidays("")
+	if err != nil {
+		t.Fatalf("LoadHolidays: %s", err)
+	}
+	cases := []struct {
+		name   string
+		t      string
+		trades bool
+	}{
+		{"平日工作日", "2026-07-27 10:00", true}, // Monday
+		{"周六", "2026-07-25 10:00", false},     // Saturday
+		{"周日", "2026-07-26 10:00", false},     // Sunday
+		{"国庆假期", "2026-10-01 10:00", false},
+		{"国庆后首个交易日", "2026-10-09 10:00", true}, // Friday after holiday block
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := IsTradingDay(mustParse(t, c.t), holidays)
+			if got != c.trades {
+				t.Fatalf("IsTradingDay(%s) = %v, want %v", c.t, got, c.trades)
+			}
+		})
+	}
+}
+
+func TestIsMarketOpen(t *testing.T) {
+	holidays, err := LoadHolidays("")
+	if err != nil {
+		t.Fatalf("LoadHolidays: %s", err)
+	}
+	cases := []struct {
+		name string
+		t    string
+		open bool
+	}{
+		{"开盘时段内", "2026-07-27 10:00", true},
+		{"早于开盘", "2026-07-27 09:00", false},
+		{"晚于收盘", "2026-07-27 15:30", false},
+		{"非交易日即使在时段内也不开盘", "2026-07-25 10:00", false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := IsMarketOpen(mustParse(t, c.t), holidays)
+			if got != c.open {
+				t.Fatalf("IsMarketOpen(%s) = %v, want %v", c.t, got, c.open)
+			}
+		})
+	}
+}
+
+func TestLoadHolidaysOverride(t *testing.T) {
+	holidays, err := LoadHolidays("testdata/override.yaml")
+	if err != nil {
+		t.Fatalf("LoadHolidays: %s", err)
+	}
+	if !holidays.IsHoliday(mustParse(t, "2026-07-27 00:00")) {
+		t.Fatalf("expected 2026-07-27 to be added as a holiday override")
+	}
+	if holidays.IsHoliday(mustParse(t, "2026-05-01 00:00")) {
+		t.Fatalf("expected 2026-05-01 to be removed by override")
+	}
+}
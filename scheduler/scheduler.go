@@ -0,0 +1,91 @@
+// Package scheduler turns vlight from a one-shot process triggered
+// externally (by cron/systemd-timer) into a long-lived daemon with its
+// own cron-style scheduler that is aware of Chinese stock-market
+// holidays and valuation-update windows.
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/hanjm/errors"
+	"github.com/robfig/cron/v3"
+)
+
+// DefaultPollSpec polls every 5 minutes during the trading day,
+// Monday-Friday, Asia/Shanghai time. Jobs added via AddPollJob still
+// skip runs outside the 09:30-15:00 valuation window and on holidays.
+const DefaultPollSpec = "*/5 9-15 * * MON-FRI"
+
+// DefaultDailySummarySpec fires once at 15:05 CST, after the market
+// closes, regardless of thresholds.
+const DefaultDailySummarySpec = "5 15 * * MON-FRI"
+
+// DefaultWeeklyDigestSpec fires once on Fridays after the daily summary,
+// aggregating the week's Gszzl per fund.
+const DefaultWeeklyDigestSpec = "10 15 * * FRI"
+
+// Scheduler wraps a robfig/cron.Cron configured for Asia/Shanghai and
+// adds holiday/market-hours awareness on top of plain cron specs.
+type Scheduler struct {
+	cron     *cron.Cron
+	loc      *time.Location
+	holidays *HolidaySet
+}
+
+// New builds a Scheduler running in Asia/Shanghai using holidays to
+// gate jobs added via AddPollJob.
+func New(holidays *HolidaySet) (*Scheduler, error) {
+	loc, err := time.LoadLocation("Asia/Shanghai")
+	if err != nil {
+		return nil, errors.Errorf(err, "load location Asia/Shanghai")
+	}
+	return &Scheduler{
+		cron:     cron.New(cron.WithLocation(loc)),
+		loc:      loc,
+		holidays: holidays,
+	}, nil
+}
+
+// AddPollJob registers fn under spec, skipping runs outside the
+// 09:30-15:00 CST valuation window and on market holidays.
+func (s *Scheduler) AddPollJob(spec string, fn func(ctx context.Context)) error {
+	_, err := s.cron.AddFunc(spec, func() {
+		now := time.Now().In(s.loc)
+		if !IsMarketOpen(now, s.holidays) {
+			return
+		}
+		fn(context.Background())
+	})
+	if err != nil {
+		return errors.Errorf(err, "add poll job, spec:%s", spec)
+	}
+	return nil
+}
+
+// AddDailyJob registers fn under spec, skipping runs on market
+// holidays but not gated to the valuation window (e.g. the 15:05 daily
+// summary that fires right after close).
+func (s *Scheduler) AddDailyJob(spec string, fn func(ctx context.Context)) error {
+	_, err := s.cron.AddFunc(spec, func() {
+		now := time.Now().In(s.loc)
+		if !IsTradingDay(now, s.holidays) {
+			return
+		}
+		fn(context.Background())
+	})
+	if err != nil {
+		return errors.Errorf(err, "add daily job, spec:%s", spec)
+	}
+	return nil
+}
+
+// Start runs the scheduler in the background.
+func (s *Scheduler) Start() {
+	s.cron.Start()
+}
+
+// Stop stops the scheduler, waiting for running jobs to finish.
+func (s *Scheduler) Stop() {
+	<-s.cron.Stop().Done()
+}
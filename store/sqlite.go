@@ -0,0 +1,76 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/hanjm/errors"
+	_ "modernc.org/sqlite" // pure-Go driver, no CGO required
+)
+
+// SQLiteStore is a Store backed by a local SQLite database file.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteStore opens (creating if needed) a SQLite database at path
+// and ensures the fund_snapshot table exists.
+func NewSQLiteStore(path string) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, errors.Errorf(err, "open sqlite, path:%s", path)
+	}
+	const schema = `
+CREATE TABLE IF NOT EXISTS fund_snapshot (
+	fundcode TEXT NOT NULL,
+	name     TEXT NOT NULL,
+	dwjz     REAL NOT NULL,
+	gsz      REAL NOT NULL,
+	gszzl    REAL NOT NULL,
+	gztime   TEXT NOT NULL,
+	PRIMARY KEY (fundcode, gztime)
+)`
+	if _, err := db.Exec(schema); err != nil {
+		_ = db.Close()
+		return nil, errors.Errorf(err, "create fund_snapshot table")
+	}
+	return &SQLiteStore{db: db}, nil
+}
+
+// Save implements Store.
+func (s *SQLiteStore) Save(ctx context.Context, snapshot Snapshot) error {
+	const stmt = `INSERT OR IGNORE INTO fund_snapshot (fundcode, name, dwjz, gsz, gszzl, gztime) VALUES (?, ?, ?, ?, ?, ?)`
+	if _, err := s.db.ExecContext(ctx, stmt, snapshot.FundCode, snapshot.Name, snapshot.Dwjz, snapshot.Gsz, snapshot.Gszzl, snapshot.Gztime); err != nil {
+		return errors.Errorf(err, "save snapshot, fundcode:%s, gztime:%s", snapshot.FundCode, snapshot.Gztime)
+	}
+	return nil
+}
+
+// History implements Store.
+func (s *SQLiteStore) History(ctx context.Context, fundCode string, since string) ([]Snapshot, error) {
+	const query = `SELECT fundcode, name, dwjz, gsz, gszzl, gztime FROM fund_snapshot WHERE fundcode = ? AND gztime >= ? ORDER BY gztime ASC`
+	rows, err := s.db.QueryContext(ctx, query, fundCode, since)
+	if err != nil {
+		return nil, errors.Errorf(err, "query history, fundcode:%s", fundCode)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	var snapshots []Snapshot
+	for rows.Next() {
+		var snapshot Snapshot
+		if err := rows.Scan(&snapshot.FundCode, &snapshot.Name, &snapshot.Dwjz, &snapshot.Gsz, &snapshot.Gszzl, &snapshot.Gztime); err != nil {
+			return nil, errors.Errorf(err, "scan history row, fundcode:%s", fundCode)
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Errorf(err, "iterate history rows, fundcode:%s", fundCode)
+	}
+	return snapshots, nil
+}
+
+// Close implements Store.
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
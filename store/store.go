@@ -0,0 +1,29 @@
+// Package store persists every fund valuation snapshot FetchFunds
+// returns so the strategy package can evaluate multi-day rules (trend,
+// moving-average crosses, new lows) instead of just the current poll.
+package store
+
+import "context"
+
+// Snapshot is one fund's valuation at a point in time, keyed by
+// (FundCode, Gztime) for deduping same-minute polls.
+type Snapshot struct {
+	FundCode string
+	Name     string
+	Dwjz     float64
+	Gsz      float64
+	Gszzl    float64
+	Gztime   string
+}
+
+// Store persists and queries fund snapshots.
+type Store interface {
+	// Save persists snapshot, ignoring it if (FundCode, Gztime) was
+	// already recorded.
+	Save(ctx context.Context, snapshot Snapshot) error
+	// History returns every snapshot for fundCode with Gztime >= since,
+	// ordered oldest first.
+	History(ctx context.Context, fundCode string, since string) ([]Snapshot, error)
+	// Close releases the store's underlying resources.
+	Close() error
+}
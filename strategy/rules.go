@@ -0,0 +1,136 @@
+package strategy
+
+import (
+	"fmt"
+
+	"github.com/hanjm/vlight/store"
+)
+
+// tail returns the last n snapshots of history (oldest first), or all
+// of them if there are fewer than n.
+func tail(history []store.Snapshot, n int) []store.Snapshot {
+	if len(history) <= n {
+		return history
+	}
+	return history[len(history)-n:]
+}
+
+// CumulativeDrop fires when the sum of Gszzl over the last days trading
+// days (including current) is at or below thresholdPercent, e.g.
+// CumulativeDrop(3, -3) for "down 3% or more over 3 days".
+func CumulativeDrop(days int, thresholdPercent float64) Rule {
+	return func(history []store.Snapshot, current store.Snapshot) (Alert, bool) {
+		window := tail(history, days-1)
+		sum := current.Gszzl
+		for _, s := range window {
+			sum += s.Gszzl
+		}
+		if sum > thresholdPercent {
+			return Alert{}, false
+		}
+		return Alert{
+			Rule:    fmt.Sprintf("cumulative_drop_%dd", days),
+			Message: fmt.Sprintf("%s累计跌幅%.2f%%，超过%d日阈值%.2f%%", current.Name, sum, days, thresholdPercent),
+		}, true
+	}
+}
+
+// movingAverage averages Dwjz over the last n snapshots of a series
+// that ends with current.
+func movingAverage(history []store.Snapshot, current store.Snapshot, n int) (float64, bool) {
+	series := append(tail(history, n-1), current)
+	if len(series) < n {
+		return 0, false
+	}
+	var sum float64
+	for _, s := range series {
+		sum += s.Dwjz
+	}
+	return sum / float64(len(series)), true
+}
+
+// MovingAverageCross fires when the short-day moving average of Dwjz
+// crosses the long-day moving average between the prior snapshot and
+// current (a golden or death cross).
+func MovingAverageCross(shortDays, longDays int) Rule {
+	return func(history []store.Snapshot, current store.Snapshot) (Alert, bool) {
+		if len(history) == 0 {
+			return Alert{}, false
+		}
+		prior := history[len(history)-1]
+		priorHistory := history[:len(history)-1]
+		shortNow, ok := movingAverage(history, current, shortDays)
+		if !ok {
+			return Alert{}, false
+		}
+		longNow, ok := movingAverage(history, current, longDays)
+		if !ok {
+			return Alert{}, false
+		}
+		shortPrior, ok := movingAverage(priorHistory, prior, shortDays)
+		if !ok {
+			return Alert{}, false
+		}
+		longPrior, ok := movingAverage(priorHistory, prior, longDays)
+		if !ok {
+			return Alert{}, false
+		}
+		wasAbove := shortPrior >= longPrior
+		isAbove := shortNow >= longNow
+		if wasAbove == isAbove {
+			return Alert{}, false
+		}
+		direction := "死叉(下穿)"
+		if isAbove {
+			direction = "金叉(上穿)"
+		}
+		return Alert{
+			Rule:    fmt.Sprintf("ma_cross_%d_%d", shortDays, longDays),
+			Message: fmt.Sprintf("%s %d日均线%s%d日均线：%.4f / %.4f", current.Name, shortDays, direction, longDays, shortNow, longNow),
+		}, true
+	}
+}
+
+// NewLow fires when current.Dwjz is the lowest value over the last days
+// trading days (including current).
+func NewLow(days int) Rule {
+	return func(history []store.Snapshot, current store.Snapshot) (Alert, bool) {
+		window := tail(history, days-1)
+		if len(window) == 0 {
+			return Alert{}, false
+		}
+		low := current.Dwjz
+		for _, s := range window {
+			if s.Dwjz < low {
+				return Alert{}, false
+			}
+		}
+		return Alert{
+			Rule:    fmt.Sprintf("new_low_%dd", days),
+			Message: fmt.Sprintf("%s单位净值%.4f创%d日新低", current.Name, current.Dwjz, days),
+		}, true
+	}
+}
+
+// ConsecutiveDown fires when current and the preceding days-1 snapshots
+// all have a negative Gszzl.
+func ConsecutiveDown(days int) Rule {
+	return func(history []store.Snapshot, current store.Snapshot) (Alert, bool) {
+		if current.Gszzl >= 0 {
+			return Alert{}, false
+		}
+		window := tail(history, days-1)
+		if len(window) < days-1 {
+			return Alert{}, false
+		}
+		for _, s := range window {
+			if s.Gszzl >= 0 {
+				return Alert{}, false
+			}
+		}
+		return Alert{
+			Rule:    fmt.Sprintf("consecutive_down_%dd", days),
+			Message: fmt.Sprintf("%s连续%d日下跌", current.Name, days),
+		}, true
+	}
+}
@@ -0,0 +1,150 @@
+package strategy
+
+import (
+	"testing"
+
+	"github.com/hanjm/vlight/store"
+)
+
+func snap(gszzl, dwjz float64) store.Snapshot {
+	return store.Snapshot{Name: "测试基金", Gszzl: gszzl, Dwjz: dwjz}
+}
+
+func TestCumulativeDrop(t *testing.T) {
+	cases := []struct {
+		name    string
+		history []store.Snapshot
+		current store.Snapshot
+		fires   bool
+	}{
+		{
+			name:    "3日累计跌幅超过阈值",
+			history: []store.Snapshot{snap(-1, 0), snap(-1.5, 0)},
+			current: snap(-1, 0),
+			fires:   true,
+		},
+		{
+			name:    "3日累计跌幅未超过阈值",
+			history: []store.Snapshot{snap(-1, 0), snap(0.5, 0)},
+			current: snap(-1, 0),
+			fires:   false,
+		},
+		{
+			name:    "history不足2日时按实际天数求和",
+			history: []store.Snapshot{snap(-2, 0)},
+			current: snap(-2, 0),
+			fires:   true,
+		},
+	}
+	rule := CumulativeDrop(3, -3)
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, ok := rule(c.history, c.current)
+			if ok != c.fires {
+				t.Fatalf("fires = %v, want %v", ok, c.fires)
+			}
+		})
+	}
+}
+
+func TestNewLow(t *testing.T) {
+	cases := []struct {
+		name    string
+		history []store.Snapshot
+		current store.Snapshot
+		fires   bool
+	}{
+		{
+			name:    "当前净值低于窗口内所有值",
+			history: []store.Snapshot{snap(0, 1.10), snap(0, 1.05)},
+			current: snap(0, 1.00),
+			fires:   true,
+		},
+		{
+			name:    "当前净值未创新低",
+			history: []store.Snapshot{snap(0, 1.10), snap(0, 0.90)},
+			current: snap(0, 1.00),
+			fires:   false,
+		},
+		{
+			name:    "没有历史数据时不触发",
+			history: nil,
+			current: snap(0, 1.00),
+			fires:   false,
+		},
+	}
+	rule := NewLow(20)
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, ok := rule(c.history, c.current)
+			if ok != c.fires {
+				t.Fatalf("fires = %v, want %v", ok, c.fires)
+			}
+		})
+	}
+}
+
+func TestConsecutiveDown(t *testing.T) {
+	cases := []struct {
+		name    string
+		history []store.Snapshot
+		current store.Snapshot
+		fires   bool
+	}{
+		{
+			name:    "连续3日下跌",
+			history: []store.Snapshot{snap(-0.1, 0), snap(-0.2, 0)},
+			current: snap(-0.3, 0),
+			fires:   true,
+		},
+		{
+			name:    "当前上涨不触发",
+			history: []store.Snapshot{snap(-0.1, 0), snap(-0.2, 0)},
+			current: snap(0.1, 0),
+			fires:   false,
+		},
+		{
+			name:    "历史中有一天未下跌不触发",
+			history: []store.Snapshot{snap(0.1, 0), snap(-0.2, 0)},
+			current: snap(-0.3, 0),
+			fires:   false,
+		},
+		{
+			name:    "历史天数不足不触发",
+			history: []store.Snapshot{snap(-0.1, 0)},
+			current: snap(-0.3, 0),
+			fires:   false,
+		},
+	}
+	rule := ConsecutiveDown(3)
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, ok := rule(c.history, c.current)
+			if ok != c.fires {
+				t.Fatalf("fires = %v, want %v", ok, c.fires)
+			}
+		})
+	}
+}
+
+func TestMovingAverageCross(t *testing.T) {
+	// 2日均线从低于5日均线变为不低于5日均线,应触发金叉
+	history := []store.Snapshot{
+		snap(0, 1.00), snap(0, 1.00), snap(0, 1.00), snap(0, 1.00), snap(0, 1.00),
+		snap(0, 0.80), // prior: short均线被拉低到低于long均线
+	}
+	current := snap(0, 1.20) // current: short均线被拉高到不低于long均线(两者均为1.00)
+	rule := MovingAverageCross(2, 5)
+	alert, ok := rule(history, current)
+	if !ok {
+		t.Fatalf("expected a cross to fire")
+	}
+	if alert.Rule != "ma_cross_2_5" {
+		t.Fatalf("rule = %q, want ma_cross_2_5", alert.Rule)
+	}
+
+	// 历史不足以计算长周期均线时不触发
+	if _, ok := rule(nil, current); ok {
+		t.Fatalf("expected no fire with empty history")
+	}
+}
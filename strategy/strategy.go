@@ -0,0 +1,43 @@
+// Package strategy evaluates alert rules richer than a single day's
+// threshold crossing: N-day cumulative drop, moving-average crosses,
+// new N-day lows, and consecutive-down-day streaks.
+package strategy
+
+import "github.com/hanjm/vlight/store"
+
+// Alert is one rule firing against a fund's history.
+type Alert struct {
+	// Rule names which rule fired, e.g. "cumulative_drop_3d".
+	Rule string
+	// Message is a human-readable description including the prior
+	// values that triggered the rule.
+	Message string
+}
+
+// Rule evaluates history (oldest first, not including current) plus the
+// current snapshot, returning an Alert if it fires.
+type Rule func(history []store.Snapshot, current store.Snapshot) (Alert, bool)
+
+// Evaluate runs every rule against history and current, returning every
+// Alert that fired.
+func Evaluate(history []store.Snapshot, current store.Snapshot, rules []Rule) []Alert {
+	var alerts []Alert
+	for _, rule := range rules {
+		if alert, ok := rule(history, current); ok {
+			alerts = append(alerts, alert)
+		}
+	}
+	return alerts
+}
+
+// DefaultRules is a reasonable rule set for daily polling: a 3-day
+// cumulative drop, a 5/20-day moving-average cross, a 20-day new low,
+// and a 3-day consecutive-down streak.
+func DefaultRules() []Rule {
+	return []Rule{
+		CumulativeDrop(3, -3),
+		MovingAverageCross(5, 20),
+		NewLow(20),
+		ConsecutiveDown(3),
+	}
+}